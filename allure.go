@@ -5,6 +5,7 @@ func (qt *cute) setAllureInformation(t allureProvider) {
 	qt.setLabelsAllure(t)
 	qt.setInfoAllure(t)
 	qt.setLinksAllure(t)
+	qt.setProfileAllure(t)
 }
 
 func (qt *cute) setLinksAllure(t linksAllureProvider) {
@@ -128,8 +128,9 @@ func (it *Test) assertBody(t internalT, body []byte) []error {
 		}
 
 		// Execute assert for response with TB
+		// T contains JSONMarshaler of test for typed asserts
 		for _, f := range assertT {
-			err := f(t, body)
+			err := f(it.withJSONMarshaler(t), body)
 			if err != nil {
 				errs = append(errs, err)
 			}
@@ -0,0 +1,108 @@
+package cute
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+
+	cuteErrors "github.com/ozontech/cute/errors"
+)
+
+// AssertJSONAs is a function for create AssertBodyT, which decodes body to V and executes assert with decoded value.
+// Body is decoded with JSONMarshaler of HTTPTestMaker (see WithJSONMarshaler).
+// If body could not be decoded, assert returns error with body in attachment.
+// Could be used with AssertBodyT, RequireBodyT, OptionalAssertBodyT and BrokenAssertBodyT.
+// Example:
+//
+//	AssertBodyT(cute.AssertJSONAs(func(t cute.T, resp *Response) error {
+//		if resp.ID == 0 {
+//			return errors.New("id is empty")
+//		}
+//		return nil
+//	}))
+func AssertJSONAs[V any](assert func(t T, v V) error) AssertBodyT {
+	return func(t T, body []byte) error {
+		v, err := decodeJSONAs[V](jsonMarshalerFromT(t), body)
+		if err != nil {
+			return err
+		}
+
+		return assert(t, v)
+	}
+}
+
+// ExecuteTestAs is a function for execute test and get response body decoded to V.
+// Body is decoded with JSONMarshaler of HTTPTestMaker (see WithJSONMarshaler).
+// If body could not be decoded, test is failed with assert error and zero value is returned.
+// Example:
+//
+//	resp, results := cute.ExecuteTestAs[*Response](ctx, t,
+//		cute.NewTestBuilder().
+//			Title("Get user").
+//			Create().
+//			RequestBuilder(cute.WithURI("http://localhost/user/1")).
+//			ExpectStatus(http.StatusOK),
+//	)
+func ExecuteTestAs[V any](ctx context.Context, t tProvider, builder ExpectHTTPBuilder) (V, []ResultsHTTPBuilder) {
+	var res V
+
+	results := builder.
+		AssertBodyT(AssertJSONAs(func(_ T, v V) error {
+			res = v
+
+			return nil
+		})).
+		ExecuteTest(ctx, t)
+
+	return res, results
+}
+
+func decodeJSONAs[V any](marshaler JSONMarshaler, body []byte) (V, error) {
+	var v V
+
+	if err := marshaler.Unmarshal(body, &v); err != nil {
+		assertErr := cuteErrors.NewEmptyAssertError(
+			"Decode JSON body",
+			fmt.Sprintf("could not decode body to %v. error: '%v'", reflect.TypeOf(&v).Elem(), err),
+		)
+		assertErr.PutAttachment(&cuteErrors.Attachment{
+			Name:     "Body",
+			MimeType: "text/plain",
+			Content:  body,
+		})
+
+		return v, assertErr
+	}
+
+	return v, nil
+}
+
+// jsonMarshalerT is T with JSONMarshaler of test
+// It is passed to body asserts, so typed asserts decode body the same way as test marshals request.
+type jsonMarshalerT struct {
+	provider.StepCtx
+
+	marshaler JSONMarshaler
+}
+
+func (it *Test) withJSONMarshaler(t T) T {
+	stepCtx, ok := t.(provider.StepCtx)
+	if !ok {
+		return t
+	}
+
+	return &jsonMarshalerT{
+		StepCtx:   stepCtx,
+		marshaler: it.jsonMarshaler,
+	}
+}
+
+func jsonMarshalerFromT(t T) JSONMarshaler {
+	if mt, ok := t.(*jsonMarshalerT); ok && mt.marshaler != nil {
+		return mt.marshaler
+	}
+
+	return jsonMarshaler{}
+}
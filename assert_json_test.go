@@ -0,0 +1,121 @@
+package cute
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cuteErrors "github.com/ozontech/cute/errors"
+)
+
+type typedResponse struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// upperNameMarshaler is marshaler, which changes value "cute" to check, that marshaler of maker is used
+type upperNameMarshaler struct{}
+
+func (upperNameMarshaler) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (upperNameMarshaler) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(bytes.ReplaceAll(data, []byte(`"cute"`), []byte(`"CUTE"`)), v)
+}
+
+func TestAssertJSONAs(t *testing.T) {
+	var (
+		got  typedResponse
+		test = &Test{
+			jsonMarshaler: upperNameMarshaler{},
+			Expect: &Expect{
+				AssertBodyT: []AssertBodyT{
+					AssertJSONAs(func(_ T, v typedResponse) error {
+						got = v
+
+						return nil
+					}),
+				},
+			},
+		}
+	)
+
+	test.initEmptyFields()
+
+	errs := test.assertBody(createAllureT(t), []byte(`{"id":1,"name":"cute"}`))
+	require.Empty(t, errs)
+	require.Equal(t, typedResponse{ID: 1, Name: "CUTE"}, got)
+}
+
+func TestAssertJSONAsReturnsAssertError(t *testing.T) {
+	test := &Test{
+		Expect: &Expect{
+			AssertBodyT: []AssertBodyT{
+				AssertJSONAs(func(_ T, v *typedResponse) error {
+					if v.ID != 2 {
+						return errors.New("id is not 2")
+					}
+
+					return nil
+				}),
+			},
+		},
+	}
+
+	test.initEmptyFields()
+
+	errs := test.assertBody(createAllureT(t), []byte(`{"id":1}`))
+	require.Len(t, errs, 1)
+	require.Equal(t, "id is not 2", errs[0].Error())
+}
+
+func TestAssertJSONAsDecodeError(t *testing.T) {
+	test := &Test{
+		Expect: &Expect{
+			AssertBodyT: []AssertBodyT{
+				AssertJSONAs(func(_ T, v typedResponse) error {
+					return errors.New("assert must not be executed")
+				}),
+			},
+		},
+	}
+
+	test.initEmptyFields()
+
+	errs := test.assertBody(createAllureT(t), []byte(`not json`))
+	require.Len(t, errs, 1)
+	require.Contains(t, errs[0].Error(), "could not decode body to cute.typedResponse")
+
+	withAttachments, ok := errs[0].(cuteErrors.WithAttachments)
+	require.True(t, ok)
+	require.Len(t, withAttachments.GetAttachments(), 1)
+	require.Equal(t, []byte(`not json`), withAttachments.GetAttachments()[0].Content)
+}
+
+func TestExecuteTestAs(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":5,"name":"cute"}`))
+	}))
+	defer ts.Close()
+
+	maker := NewHTTPTestMaker(WithJSONMarshaler(upperNameMarshaler{}))
+
+	resp, results := ExecuteTestAs[*typedResponse](context.Background(), t,
+		maker.NewTestBuilder().
+			Create().
+			RequestBuilder(WithURI(ts.URL)).
+			ExpectStatus(http.StatusOK),
+	)
+
+	require.Len(t, results, 1)
+	require.Empty(t, results[0].GetErrors())
+	require.Equal(t, &typedResponse{ID: 5, Name: "CUTE"}, resp)
+}
@@ -35,6 +35,24 @@ func assertResponseWithTrace(assert AssertResponse, trace string) AssertResponse
 	}
 }
 
+// assertRaceWithTrace is a function to add trace inside assert race error
+func assertRaceWithTrace(assert AssertRace, trace string) AssertRace {
+	return func(responses []*http.Response) error {
+		err := assert(responses)
+
+		return wrapWithTrace(err, trace)
+	}
+}
+
+// assertItemsWithTrace is a function to add trace inside assert items error
+func assertItemsWithTrace(assert AssertItems, trace string) AssertItems {
+	return func(items []interface{}) error {
+		err := assert(items)
+
+		return wrapWithTrace(err, trace)
+	}
+}
+
 // assertHeadersTWithTrace is a function to add trace inside assert headers error
 func assertHeadersTWithTrace(assert AssertHeadersT, trace string) AssertHeadersT {
 	return func(t T, headers http.Header) error {
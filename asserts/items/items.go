@@ -0,0 +1,140 @@
+package items
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ohler55/ojg/jp"
+
+	"github.com/ozontech/cute"
+	"github.com/ozontech/cute/errors"
+)
+
+// Count is a function to asserts that total count of items of all pages is equal to count
+func Count(count int) cute.AssertItems {
+	return func(items []interface{}) error {
+		if len(items) != count {
+			return errors.NewAssertError("Count", fmt.Sprintf("expected %v items, but was %v", count, len(items)), len(items), count)
+		}
+
+		return nil
+	}
+}
+
+// Unique is a function to asserts that jsonpath expression value is unique for every item
+// Expression is applied to item, for example $.id
+func Unique(expression string) cute.AssertItems {
+	return func(items []interface{}) error {
+		values, err := itemValues(items, expression)
+		if err != nil {
+			return errors.NewAssertError("Unique", err.Error(), nil, nil)
+		}
+
+		seen := make(map[string]int, len(values))
+
+		for i, value := range values {
+			key := fmt.Sprintf("%T:%v", value, value)
+
+			if first, ok := seen[key]; ok {
+				return errors.NewAssertError("Unique", fmt.Sprintf("on path %v item %v has the same value %v as item %v", expression, i+1, value, first+1), value, nil)
+			}
+
+			seen[key] = i
+		}
+
+		return nil
+	}
+}
+
+// Sorted is a function to asserts that items are sorted in ascending order by jsonpath expression value
+// Values have to be numbers or strings. Order is checked across page boundaries.
+func Sorted(expression string) cute.AssertItems {
+	return sorted("Sorted", expression, false)
+}
+
+// SortedDesc is a function to asserts that items are sorted in descending order by jsonpath expression value
+// Values have to be numbers or strings. Order is checked across page boundaries.
+func SortedDesc(expression string) cute.AssertItems {
+	return sorted("SortedDesc", expression, true)
+}
+
+func sorted(name, expression string, desc bool) cute.AssertItems {
+	return func(items []interface{}) error {
+		values, err := itemValues(items, expression)
+		if err != nil {
+			return errors.NewAssertError(name, err.Error(), nil, nil)
+		}
+
+		for i := 1; i < len(values); i++ {
+			res, err := compare(values[i-1], values[i])
+			if err != nil {
+				return errors.NewAssertError(name, fmt.Sprintf("on path %v item %v: %v", expression, i+1, err), values[i], values[i-1])
+			}
+
+			if (!desc && res > 0) || (desc && res < 0) {
+				return errors.NewAssertError(name, fmt.Sprintf("on path %v item %v has %v after %v", expression, i+1, values[i], values[i-1]), values[i], values[i-1])
+			}
+		}
+
+		return nil
+	}
+}
+
+// itemValues returns value of every item by jsonpath expression
+func itemValues(items []interface{}, expression string) ([]interface{}, error) {
+	path, err := jp.ParseString(expression)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse path %v error: '%s'", expression, err)
+	}
+
+	values := make([]interface{}, len(items))
+
+	for i, item := range items {
+		found := path.Get(item)
+		if len(found) == 0 {
+			return nil, fmt.Errorf("could not find element by path %v in item %v", expression, i+1)
+		}
+
+		values[i] = found[0]
+	}
+
+	return values, nil
+}
+
+// compare returns -1, 0 or 1, if a is less, equal or greater than b
+func compare(a, b interface{}) (int, error) {
+	if x, ok := toFloat(a); ok {
+		if y, ok := toFloat(b); ok {
+			switch {
+			case x < y:
+				return -1, nil
+			case x > y:
+				return 1, nil
+			default:
+				return 0, nil
+			}
+		}
+	}
+
+	x, okA := a.(string)
+	y, okB := b.(string)
+
+	if !okA || !okB {
+		return 0, fmt.Errorf("could not compare %v and %v, values have to be numbers or strings", a, b)
+	}
+
+	return strings.Compare(x, y), nil
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,37 @@
+package items
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newItems(ids ...interface{}) []interface{} {
+	res := make([]interface{}, len(ids))
+
+	for i, id := range ids {
+		res[i] = map[string]interface{}{"id": id}
+	}
+
+	return res
+}
+
+func TestCount(t *testing.T) {
+	require.NoError(t, Count(3)(newItems(1, 2, 3)))
+	require.EqualError(t, Count(2)(newItems(1, 2, 3)), "expected 2 items, but was 3")
+}
+
+func TestUnique(t *testing.T) {
+	require.NoError(t, Unique("$.id")(newItems(int64(1), int64(2), "1")))
+	require.EqualError(t, Unique("$.id")(newItems("a", "b", "a")), "on path $.id item 3 has the same value a as item 1")
+	require.EqualError(t, Unique("$.name")(newItems("a")), "could not find element by path $.name in item 1")
+}
+
+func TestSorted(t *testing.T) {
+	require.NoError(t, Sorted("$.id")(newItems(int64(1), 2.5, int64(2e1))))
+	require.NoError(t, Sorted("$.id")(newItems("a", "a", "b")))
+	require.NoError(t, SortedDesc("$.id")(newItems(int64(3), int64(2), int64(2))))
+	require.EqualError(t, Sorted("$.id")(newItems(int64(1), int64(3), int64(2))), "on path $.id item 3 has 2 after 3")
+	require.EqualError(t, SortedDesc("$.id")(newItems("a", "b")), "on path $.id item 2 has b after a")
+	require.EqualError(t, Sorted("$.id")(newItems(int64(1), "b")), "on path $.id item 2: could not compare 1 and b, values have to be numbers or strings")
+}
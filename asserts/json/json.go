@@ -2,40 +2,18 @@ package json
 
 import (
 	"fmt"
+	"time"
 
-	jd "github.com/josephburnett/jd/lib"
 	"github.com/ohler55/ojg/jp"
 	"github.com/ohler55/ojg/oj"
 	"github.com/ozontech/cute"
-	cuteErrors "github.com/ozontech/cute/errors"
+	"github.com/ozontech/cute/internal/jsondiff"
 )
 
 // Diff is a function to compare two jsons
 func Diff(original string) cute.AssertBody {
 	return func(body []byte) error {
-		originalJSON, err := jd.ReadJsonString(original)
-		if err != nil {
-			return fmt.Errorf("could not parse original json in Diff error: '%s'", err)
-		}
-
-		bodyJSON, err := jd.ReadJsonString(string(body))
-		if err != nil {
-			return fmt.Errorf("could not parse body json in Diff error: '%s'", err)
-		}
-
-		diff := originalJSON.Diff(bodyJSON).Render()
-		if diff != "" {
-			cErr := cuteErrors.NewEmptyAssertError("JSON Diff", "JSON is not the same")
-			cErr.PutAttachment(&cuteErrors.Attachment{
-				Name:     "JSON diff",
-				MimeType: "text/plain",
-				Content:  []byte(diff),
-			})
-
-			return cErr
-		}
-
-		return nil
+		return jsondiff.Compare([]byte(original), body)
 	}
 }
 
@@ -145,8 +123,113 @@ func NotPresent(expression string) cute.AssertBody {
 	}
 }
 
+// GreaterThan is a function to asserts that number value is greater than the given number
+// About expression - https://goessner.net/articles/JsonPath/
+func GreaterThan(expression string, minimum float64) cute.AssertBody {
+	return func(body []byte) error {
+		return numberGreaterThan(body, expression, minimum)
+	}
+}
+
+// LessThan is a function to asserts that number value is less than the given number
+// About expression - https://goessner.net/articles/JsonPath/
+func LessThan(expression string, maximum float64) cute.AssertBody {
+	return func(body []byte) error {
+		return numberLessThan(body, expression, maximum)
+	}
+}
+
+// Between is a function to asserts that number value is between minimum and maximum inclusive
+// About expression - https://goessner.net/articles/JsonPath/
+func Between(expression string, minimum, maximum float64) cute.AssertBody {
+	return func(body []byte) error {
+		return between(body, expression, minimum, maximum)
+	}
+}
+
+// InDelta is a function to asserts that number value is equal to expected number within delta
+// About expression - https://goessner.net/articles/JsonPath/
+func InDelta(expression string, expect, delta float64) cute.AssertBody {
+	return func(body []byte) error {
+		return inDelta(body, expression, expect, delta)
+	}
+}
+
+// Matches is a function to asserts that string value matches the regular expression
+// About expression - https://goessner.net/articles/JsonPath/
+func Matches(expression string, pattern string) cute.AssertBody {
+	return func(body []byte) error {
+		return matches(body, expression, pattern)
+	}
+}
+
+// IsType is a function to asserts that value has the JSON type
+// Available types: TypeString, TypeNumber, TypeBool, TypeObject, TypeArray, TypeNull
+// About expression - https://goessner.net/articles/JsonPath/
+func IsType(expression string, expectType string) cute.AssertBody {
+	return func(body []byte) error {
+		return isType(body, expression, expectType)
+	}
+}
+
+// OneOf is a function to asserts that value is equal to one of the given values
+// About expression - https://goessner.net/articles/JsonPath/
+func OneOf(expression string, expect ...interface{}) cute.AssertBody {
+	return func(body []byte) error {
+		return oneOf(body, expression, expect)
+	}
+}
+
+// IsUUID is a function to asserts that value is a string with UUID
+// About expression - https://goessner.net/articles/JsonPath/
+func IsUUID(expression string) cute.AssertBody {
+	return func(body []byte) error {
+		return isUUID(body, expression)
+	}
+}
+
+// IsEmail is a function to asserts that value is a string with email address
+// About expression - https://goessner.net/articles/JsonPath/
+func IsEmail(expression string) cute.AssertBody {
+	return func(body []byte) error {
+		return isEmail(body, expression)
+	}
+}
+
+// IsRFC3339 is a function to asserts that value is a string with date in RFC 3339 format
+// About expression - https://goessner.net/articles/JsonPath/
+func IsRFC3339(expression string) cute.AssertBody {
+	return func(body []byte) error {
+		return isRFC3339(body, expression)
+	}
+}
+
+// WithinDurationOfNow is a function to asserts that value is a date in RFC 3339 format
+// and differs from current time not more than delta
+// About expression - https://goessner.net/articles/JsonPath/
+func WithinDurationOfNow(expression string, delta time.Duration) cute.AssertBody {
+	return func(body []byte) error {
+		return withinDurationOfNow(body, expression, delta)
+	}
+}
+
 // GetValueFromJSON is function for get value from json
 func GetValueFromJSON(js []byte, expression string) ([]interface{}, error) {
+	res, err := findValues(js, expression)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("could not find element by path %v in JSON", expression)
+	}
+
+	return res, nil
+}
+
+// findValues is function for get values from json
+// If nothing is found by expression, empty slice is returned without error
+func findValues(js []byte, expression string) ([]interface{}, error) {
 	obj, err := oj.Parse(js)
 	if err != nil {
 		return nil, fmt.Errorf("could not parse json in GetValueFromJSON error: '%s'", err)
@@ -157,11 +240,5 @@ func GetValueFromJSON(js []byte, expression string) ([]interface{}, error) {
 		return nil, fmt.Errorf("could not parse path in GetValueFromJSON error: '%s'", err)
 	}
 
-	res := jsonPath.Get(obj)
-
-	if len(res) == 0 {
-		return nil, fmt.Errorf("could not find element by path %v in JSON", expression)
-	}
-
-	return res, nil
+	return jsonPath.Get(obj), nil
 }
@@ -0,0 +1,299 @@
+package json
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ohler55/ojg/oj"
+	"github.com/ozontech/cute"
+	"github.com/ozontech/cute/errors"
+)
+
+type quantifierKind int
+
+const (
+	quantifierAll quantifierKind = iota
+	quantifierAny
+	quantifierNone
+	quantifierExactly
+)
+
+// Quantifier defines how many values, found by expression, have to pass assert.
+// Asserts without quantifier require all values to pass and at least one value to be found.
+// Example:
+//
+//	json.Any.Equal("$.items[*].status", "done")
+//	json.None.Equal("$.items[*].status", "failed")
+//	json.Exactly(2).IsType("$.items[*].id", json.TypeNumber)
+type Quantifier struct {
+	kind  quantifierKind
+	count int
+}
+
+var (
+	// All requires all values to pass assert. At least one value has to be found.
+	All = Quantifier{kind: quantifierAll}
+	// Any requires at least one value to pass assert.
+	Any = Quantifier{kind: quantifierAny}
+	// None requires no value to pass assert. If nothing is found, assert passes.
+	None = Quantifier{kind: quantifierNone}
+)
+
+// Exactly requires exactly n values to pass assert.
+func Exactly(n int) Quantifier {
+	return Quantifier{kind: quantifierExactly, count: n}
+}
+
+// String returns name of quantifier
+func (q Quantifier) String() string {
+	switch q.kind {
+	case quantifierAny:
+		return "Any"
+	case quantifierNone:
+		return "None"
+	case quantifierExactly:
+		return fmt.Sprintf("Exactly(%v)", q.count)
+	default:
+		return "All"
+	}
+}
+
+// Equal is a function to assert that values of jsonpath expression match the given value
+// About expression - https://goessner.net/articles/JsonPath/
+func (q Quantifier) Equal(expression string, expect interface{}) cute.AssertBody {
+	return func(body []byte) error {
+		return q.check(body, expression, "Equal", expect, equalCheck(expect, "Equal"))
+	}
+}
+
+// NotEqual is a function to check values of json path expression are not equal to given value
+// About expression - https://goessner.net/articles/JsonPath/
+func (q Quantifier) NotEqual(expression string, expect interface{}) cute.AssertBody {
+	return func(body []byte) error {
+		return q.check(body, expression, "NotEqual", expect, notEqualCheck(expect, "NotEqual"))
+	}
+}
+
+// EqualJSON is a function to check values of json path expression are equal to given json
+// About expression - https://goessner.net/articles/JsonPath/
+func (q Quantifier) EqualJSON(expression string, expect []byte) cute.AssertBody {
+	return func(body []byte) error {
+		obj, err := oj.Parse(expect)
+		if err != nil {
+			return fmt.Errorf("could not parse json in EqualJSON error: '%s'", err)
+		}
+
+		return q.check(body, expression, "EqualJSON", obj, equalCheck(obj, "EqualJSON"))
+	}
+}
+
+// Contains is a function to assert that values of jsonpath expression contain the given value
+// About expression - https://goessner.net/articles/JsonPath/
+func (q Quantifier) Contains(expression string, expect interface{}) cute.AssertBody {
+	return func(body []byte) error {
+		return q.check(body, expression, "Contains", expect, containsCheck(expect))
+	}
+}
+
+// Length is a function to asserts that values have the expected length
+// About expression - https://goessner.net/articles/JsonPath/
+func (q Quantifier) Length(expression string, expectLength int) cute.AssertBody {
+	return func(body []byte) error {
+		return q.check(body, expression, "Length", expectLength, lengthCheck(expectLength))
+	}
+}
+
+// NotEmpty is a function to asserts that values are not empty (!= 0, != null)
+// About expression - https://goessner.net/articles/JsonPath/
+func (q Quantifier) NotEmpty(expression string) cute.AssertBody {
+	return func(body []byte) error {
+		return q.check(body, expression, "NotEmpty", "not empty", func(expression string, value interface{}) error {
+			if isEmpty(value) {
+				return errors.NewAssertError("NotEmpty", fmt.Sprintf("on path %v. value is empty", expression), value, "not empty")
+			}
+
+			return nil
+		})
+	}
+}
+
+// GreaterThan is a function to asserts that number values are greater than the given number
+// About expression - https://goessner.net/articles/JsonPath/
+func (q Quantifier) GreaterThan(expression string, minimum float64) cute.AssertBody {
+	return func(body []byte) error {
+		return q.check(body, expression, "GreaterThan", minimum, greaterThanCheck(minimum))
+	}
+}
+
+// LessThan is a function to asserts that number values are less than the given number
+// About expression - https://goessner.net/articles/JsonPath/
+func (q Quantifier) LessThan(expression string, maximum float64) cute.AssertBody {
+	return func(body []byte) error {
+		return q.check(body, expression, "LessThan", maximum, lessThanCheck(maximum))
+	}
+}
+
+// Between is a function to asserts that number values are between minimum and maximum inclusive
+// About expression - https://goessner.net/articles/JsonPath/
+func (q Quantifier) Between(expression string, minimum, maximum float64) cute.AssertBody {
+	return func(body []byte) error {
+		return q.check(body, expression, "Between", fmt.Sprintf("[%v, %v]", minimum, maximum), betweenCheck(minimum, maximum))
+	}
+}
+
+// InDelta is a function to asserts that number values are equal to expected number within delta
+// About expression - https://goessner.net/articles/JsonPath/
+func (q Quantifier) InDelta(expression string, expect, delta float64) cute.AssertBody {
+	return func(body []byte) error {
+		return q.check(body, expression, "InDelta", expect, inDeltaCheck(expect, delta))
+	}
+}
+
+// Matches is a function to asserts that string values match the regular expression
+// About expression - https://goessner.net/articles/JsonPath/
+func (q Quantifier) Matches(expression string, pattern string) cute.AssertBody {
+	return func(body []byte) error {
+		check, err := matchesCheck(pattern)
+		if err != nil {
+			return err
+		}
+
+		return q.check(body, expression, "Matches", pattern, check)
+	}
+}
+
+// IsType is a function to asserts that values have the JSON type
+// About expression - https://goessner.net/articles/JsonPath/
+func (q Quantifier) IsType(expression string, expectType string) cute.AssertBody {
+	return func(body []byte) error {
+		return q.check(body, expression, "IsType", expectType, typeCheck(expectType))
+	}
+}
+
+// OneOf is a function to asserts that values are equal to one of the given values
+// About expression - https://goessner.net/articles/JsonPath/
+func (q Quantifier) OneOf(expression string, expect ...interface{}) cute.AssertBody {
+	return func(body []byte) error {
+		return q.check(body, expression, "OneOf", expect, oneOfCheck(expect))
+	}
+}
+
+// IsUUID is a function to asserts that values are strings with UUID
+// About expression - https://goessner.net/articles/JsonPath/
+func (q Quantifier) IsUUID(expression string) cute.AssertBody {
+	return func(body []byte) error {
+		return q.check(body, expression, "IsUUID", "UUID", uuidCheck())
+	}
+}
+
+// IsEmail is a function to asserts that values are strings with email address
+// About expression - https://goessner.net/articles/JsonPath/
+func (q Quantifier) IsEmail(expression string) cute.AssertBody {
+	return func(body []byte) error {
+		return q.check(body, expression, "IsEmail", "email", emailCheck())
+	}
+}
+
+// IsRFC3339 is a function to asserts that values are strings with date in RFC 3339 format
+// About expression - https://goessner.net/articles/JsonPath/
+func (q Quantifier) IsRFC3339(expression string) cute.AssertBody {
+	return func(body []byte) error {
+		return q.check(body, expression, "IsRFC3339", time.RFC3339, rfc3339Check())
+	}
+}
+
+// WithinDurationOfNow is a function to asserts that values are dates in RFC 3339 format
+// and differ from current time not more than delta
+// About expression - https://goessner.net/articles/JsonPath/
+func (q Quantifier) WithinDurationOfNow(expression string, delta time.Duration) cute.AssertBody {
+	return func(body []byte) error {
+		return q.check(body, expression, "WithinDurationOfNow", fmt.Sprintf("now ± %v", delta), withinDurationOfNowCheck(delta))
+	}
+}
+
+// check executes check for every value and compares count of passed values with quantifier
+// Error message contains indices of values, which broke quantifier.
+func (q Quantifier) check(data []byte, expression, name string, expect interface{}, check valueCheck) error {
+	values, err := findValues(data, expression)
+	if err != nil {
+		return err
+	}
+
+	var (
+		passed   = make([]int, 0, len(values))
+		failed   = make([]int, 0, len(values))
+		failures = make([]string, 0, len(values))
+	)
+
+	for i, value := range values {
+		if checkErr := check(expression, value); checkErr != nil {
+			failed = append(failed, i)
+			failures = append(failures, fmt.Sprintf("[%v] %v", i, checkErr.Error()))
+
+			continue
+		}
+
+		passed = append(passed, i)
+	}
+
+	var (
+		assertName = fmt.Sprintf("%v %v", q, name)
+		message    string
+		actual     []interface{}
+	)
+
+	switch q.kind {
+	case quantifierAll:
+		if len(values) == 0 {
+			return errors.NewAssertError(assertName, fmt.Sprintf("on path %v. values are not found", expression), nil, expect)
+		}
+
+		if len(failed) == 0 {
+			return nil
+		}
+
+		message = fmt.Sprintf("on path %v. expect all values pass, but failed indices %v\n%v",
+			expression, failed, strings.Join(failures, "\n"))
+		actual = valuesByIndices(values, failed)
+	case quantifierAny:
+		if len(passed) != 0 {
+			return nil
+		}
+
+		if len(values) == 0 {
+			return errors.NewAssertError(assertName, fmt.Sprintf("on path %v. values are not found", expression), nil, expect)
+		}
+
+		message = fmt.Sprintf("on path %v. expect any value pass, but all values failed, indices %v\n%v",
+			expression, failed, strings.Join(failures, "\n"))
+		actual = values
+	case quantifierNone:
+		if len(passed) == 0 {
+			return nil
+		}
+
+		message = fmt.Sprintf("on path %v. expect no value pass, but passed indices %v", expression, passed)
+		actual = valuesByIndices(values, passed)
+	case quantifierExactly:
+		if len(passed) == q.count {
+			return nil
+		}
+
+		message = fmt.Sprintf("on path %v. expect exactly %v values pass, but passed %v with indices %v, failed indices %v\n%v",
+			expression, q.count, len(passed), passed, failed, strings.Join(failures, "\n"))
+		actual = valuesByIndices(values, passed)
+	}
+
+	return errors.NewAssertError(assertName, strings.TrimSpace(message), actual, expect)
+}
+
+func valuesByIndices(values []interface{}, indices []int) []interface{} {
+	res := make([]interface{}, 0, len(indices))
+
+	for _, i := range indices {
+		res = append(res, values[i])
+	}
+
+	return res
+}
@@ -0,0 +1,59 @@
+package json
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/cute/errors"
+)
+
+const itemsJSON = `{"items": [{"status": "done", "price": 10}, {"status": "new", "price": 20}, {"status": "done", "price": 30}]}`
+
+func TestQuantifierAll(t *testing.T) {
+	require.NoError(t, All.GreaterThan("$.items[*].price", 5)([]byte(itemsJSON)))
+	require.Error(t, All.Equal("$.items[*].status", "done")([]byte(itemsJSON)))
+	require.Error(t, All.Equal("$.not_found[*]", "done")([]byte(itemsJSON)))
+
+	err := All.Equal("$.items[*].status", "done")([]byte(itemsJSON))
+	require.Contains(t, err.Error(), "failed indices [1]")
+
+	fields := err.(errors.WithFields).GetFields()
+	require.Equal(t, []interface{}{"new"}, fields[errors.ActualField])
+	require.Equal(t, "done", fields[errors.ExpectedField])
+}
+
+func TestQuantifierAny(t *testing.T) {
+	require.NoError(t, Any.Equal("$.items[*].status", "new")([]byte(itemsJSON)))
+	require.NoError(t, Any.GreaterThan("$.items[*].price", 25)([]byte(itemsJSON)))
+	require.Error(t, Any.Equal("$.not_found[*]", "new")([]byte(itemsJSON)))
+
+	err := Any.Equal("$.items[*].status", "failed")([]byte(itemsJSON))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "indices [0 1 2]")
+}
+
+func TestQuantifierNone(t *testing.T) {
+	require.NoError(t, None.Equal("$.items[*].status", "failed")([]byte(itemsJSON)))
+	require.NoError(t, None.Equal("$.not_found[*]", "failed")([]byte(itemsJSON)))
+
+	err := None.Equal("$.items[*].status", "done")([]byte(itemsJSON))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "passed indices [0 2]")
+}
+
+func TestQuantifierExactly(t *testing.T) {
+	require.NoError(t, Exactly(2).Equal("$.items[*].status", "done")([]byte(itemsJSON)))
+	require.NoError(t, Exactly(1).Between("$.items[*].price", 15, 25)([]byte(itemsJSON)))
+	require.NoError(t, Exactly(0).Equal("$.items[*].status", "failed")([]byte(itemsJSON)))
+
+	err := Exactly(1).Equal("$.items[*].status", "done")([]byte(itemsJSON))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "passed 2 with indices [0 2], failed indices [1]")
+	require.Equal(t, "Exactly(1) Equal", err.(errors.WithNameError).GetName())
+}
+
+func TestQuantifierNotValidJSON(t *testing.T) {
+	require.Error(t, None.Equal("$.a", 1)([]byte(`{not_valid_json}`)))
+	require.Error(t, Any.Matches("$.a", `(`)([]byte(`{"a": "b"}`)))
+}
@@ -10,16 +10,34 @@ import (
 	"github.com/ozontech/cute/errors"
 )
 
-// Contains is a function to assert that a jsonpath expression extracts a value in an array
-// Given the response is {"first": 777, "second": [{"key_1": "some_key", "value": "some_value"}]}, we can assert on the result like so `$.second[? @.key_1=="some_key"].value`, "some_value"
-// About expression - https://goessner.net/articles/JsonPath/
-func contains(data []byte, expression string, expect interface{}) error {
+// valueCheck is a check of one value, which was found by expression
+type valueCheck func(expression string, value interface{}) error
+
+// checkValues executes check for every value, which was found by expression, and returns first error
+func checkValues(data []byte, expression string, check valueCheck) error {
 	values, err := GetValueFromJSON(data, expression)
 	if err != nil {
 		return err
 	}
 
 	for _, value := range values {
+		if err = check(expression, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Contains is a function to assert that a jsonpath expression extracts a value in an array
+// Given the response is {"first": 777, "second": [{"key_1": "some_key", "value": "some_value"}]}, we can assert on the result like so `$.second[? @.key_1=="some_key"].value`, "some_value"
+// About expression - https://goessner.net/articles/JsonPath/
+func contains(data []byte, expression string, expect interface{}) error {
+	return checkValues(data, expression, containsCheck(expect))
+}
+
+func containsCheck(expect interface{}) valueCheck {
+	return func(expression string, value interface{}) error {
 		ok, found := insideArray(value, expect)
 		if !ok {
 			return errors.NewAssertError("Contains", fmt.Sprintf("on path %v. %v could not be applied builtin len()", expression, expect), nil, nil)
@@ -28,39 +46,37 @@ func contains(data []byte, expression string, expect interface{}) error {
 		if !found {
 			return errors.NewAssertError("Contains", fmt.Sprintf("on path %v. expect %v, but actual %v", expression, expect, value), value, expect)
 		}
-	}
 
-	return nil
+		return nil
+	}
 }
 
 func equalAbstract(data []byte, expression string, expect interface{}, name string) error {
-	values, err := GetValueFromJSON(data, expression)
-	if err != nil {
-		return err
-	}
+	return checkValues(data, expression, equalCheck(expect, name))
+}
 
-	for _, value := range values {
+func equalCheck(expect interface{}, name string) valueCheck {
+	return func(expression string, value interface{}) error {
 		if !objectsAreEqual(value, expect) {
 			return errors.NewAssertError(name, fmt.Sprintf("on path %v. expect %v, but actual %v", expression, expect, value), value, expect)
 		}
-	}
 
-	return nil
+		return nil
+	}
 }
 
 func notEqualAbstract(data []byte, expression string, expect interface{}, name string) error {
-	values, err := GetValueFromJSON(data, expression)
-	if err != nil {
-		return err
-	}
+	return checkValues(data, expression, notEqualCheck(expect, name))
+}
 
-	for _, value := range values {
+func notEqualCheck(expect interface{}, name string) valueCheck {
+	return func(expression string, value interface{}) error {
 		if objectsAreEqual(value, expect) {
 			return errors.NewAssertError(name, fmt.Sprintf("on path %v. expect %v, but actual %v", expression, expect, value), value, expect)
 		}
-	}
 
-	return nil
+		return nil
+	}
 }
 
 // Equal is a function to assert that a jsonpath expression matches the given value
@@ -100,19 +116,22 @@ func notEqualJSON(data []byte, expression string, expect []byte) error {
 // Length is a function to asserts that value is the expected length
 // About expression - https://goessner.net/articles/JsonPath/
 func length(data []byte, expression string, expectLength int) error {
-	values, err := GetValueFromJSON(data, expression)
-	if err != nil {
-		return err
-	}
+	return checkValues(data, expression, lengthCheck(expectLength))
+}
 
-	for _, value := range values {
+func lengthCheck(expectLength int) valueCheck {
+	return func(expression string, value interface{}) error {
 		v := reflect.ValueOf(value)
+		if !hasLength(v) {
+			return errors.NewAssertError("Length", fmt.Sprintf("on path %v. %v could not be applied builtin len()", expression, value), value, expectLength)
+		}
+
 		if v.Len() != expectLength {
 			return errors.NewAssertError("Length", fmt.Sprintf("on path %v. expect lenght %v, but actual %v", expression, expectLength, v.Len()), v.Len(), expectLength)
 		}
-	}
 
-	return nil
+		return nil
+	}
 }
 
 // GreaterThan is a function to asserts that value is greater than the given length
@@ -261,6 +280,15 @@ func objectsAreEqual(expect, actual interface{}) bool {
 	return bytes.Equal(exp, act)
 }
 
+func hasLength(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Chan, reflect.Map, reflect.Slice, reflect.String:
+		return true
+	default:
+		return false
+	}
+}
+
 func isEmpty(object interface{}) bool {
 	if object == nil {
 		return true
@@ -0,0 +1,282 @@
+package json
+
+import (
+	"fmt"
+	"math"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"time"
+
+	"github.com/ozontech/cute/errors"
+)
+
+// JSON types for IsType assert
+const (
+	TypeString = "string"
+	TypeNumber = "number"
+	TypeBool   = "bool"
+	TypeObject = "object"
+	TypeArray  = "array"
+	TypeNull   = "null"
+)
+
+var uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// numberGreaterThan is a function to asserts that number value is greater than the given number
+// About expression - https://goessner.net/articles/JsonPath/
+func numberGreaterThan(data []byte, expression string, minimum float64) error {
+	return checkValues(data, expression, greaterThanCheck(minimum))
+}
+
+func greaterThanCheck(minimum float64) valueCheck {
+	return numberCheck("GreaterThan", func(value float64) bool {
+		return value > minimum
+	}, func(expression string, value interface{}) error {
+		return errors.NewAssertError("GreaterThan", fmt.Sprintf("on path %v. expect greater than %v, but actual %v", expression, minimum, value), value, minimum)
+	})
+}
+
+// numberLessThan is a function to asserts that number value is less than the given number
+// About expression - https://goessner.net/articles/JsonPath/
+func numberLessThan(data []byte, expression string, maximum float64) error {
+	return checkValues(data, expression, lessThanCheck(maximum))
+}
+
+func lessThanCheck(maximum float64) valueCheck {
+	return numberCheck("LessThan", func(value float64) bool {
+		return value < maximum
+	}, func(expression string, value interface{}) error {
+		return errors.NewAssertError("LessThan", fmt.Sprintf("on path %v. expect less than %v, but actual %v", expression, maximum, value), value, maximum)
+	})
+}
+
+// between is a function to asserts that number value is between minimum and maximum inclusive
+// About expression - https://goessner.net/articles/JsonPath/
+func between(data []byte, expression string, minimum, maximum float64) error {
+	return checkValues(data, expression, betweenCheck(minimum, maximum))
+}
+
+func betweenCheck(minimum, maximum float64) valueCheck {
+	expect := fmt.Sprintf("[%v, %v]", minimum, maximum)
+
+	return numberCheck("Between", func(value float64) bool {
+		return value >= minimum && value <= maximum
+	}, func(expression string, value interface{}) error {
+		return errors.NewAssertError("Between", fmt.Sprintf("on path %v. expect value in %v, but actual %v", expression, expect, value), value, expect)
+	})
+}
+
+// inDelta is a function to asserts that number value is equal to expected number within delta
+// About expression - https://goessner.net/articles/JsonPath/
+func inDelta(data []byte, expression string, expect, delta float64) error {
+	return checkValues(data, expression, inDeltaCheck(expect, delta))
+}
+
+func inDeltaCheck(expect, delta float64) valueCheck {
+	return numberCheck("InDelta", func(value float64) bool {
+		return math.Abs(value-expect) <= delta
+	}, func(expression string, value interface{}) error {
+		return errors.NewAssertError("InDelta", fmt.Sprintf("on path %v. expect %v ± %v, but actual %v", expression, expect, delta, value), value, expect)
+	})
+}
+
+func numberCheck(name string, check func(value float64) bool, fail valueCheck) valueCheck {
+	return func(expression string, value interface{}) error {
+		number, ok := toFloat(value)
+		if !ok {
+			return errors.NewAssertError(name, fmt.Sprintf("on path %v. value %v is not a number", expression, value), value, nil)
+		}
+
+		if !check(number) {
+			return fail(expression, value)
+		}
+
+		return nil
+	}
+}
+
+// matches is a function to asserts that string value matches the regular expression
+// About expression - https://goessner.net/articles/JsonPath/
+func matches(data []byte, expression string, pattern string) error {
+	check, err := matchesCheck(pattern)
+	if err != nil {
+		return err
+	}
+
+	return checkValues(data, expression, check)
+}
+
+func matchesCheck(pattern string) (valueCheck, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("could not compile pattern in Matches error: '%s'", err)
+	}
+
+	return stringCheck("Matches", func(expression string, value string) error {
+		if !re.MatchString(value) {
+			return errors.NewAssertError("Matches", fmt.Sprintf("on path %v. expect value matches %v, but actual %v", expression, pattern, value), value, pattern)
+		}
+
+		return nil
+	}), nil
+}
+
+// isUUID is a function to asserts that value is a string with UUID
+// About expression - https://goessner.net/articles/JsonPath/
+func isUUID(data []byte, expression string) error {
+	return checkValues(data, expression, uuidCheck())
+}
+
+func uuidCheck() valueCheck {
+	return stringCheck("IsUUID", func(expression string, value string) error {
+		if !uuidRegexp.MatchString(value) {
+			return errors.NewAssertError("IsUUID", fmt.Sprintf("on path %v. expect UUID, but actual %v", expression, value), value, "UUID")
+		}
+
+		return nil
+	})
+}
+
+// isEmail is a function to asserts that value is a string with email address
+// Address with display name, like "Name <name@example.com>", is not an email.
+// About expression - https://goessner.net/articles/JsonPath/
+func isEmail(data []byte, expression string) error {
+	return checkValues(data, expression, emailCheck())
+}
+
+func emailCheck() valueCheck {
+	return stringCheck("IsEmail", func(expression string, value string) error {
+		address, err := mail.ParseAddress(value)
+		if err != nil || address.Address != value {
+			return errors.NewAssertError("IsEmail", fmt.Sprintf("on path %v. expect email, but actual %v", expression, value), value, "email")
+		}
+
+		return nil
+	})
+}
+
+// isRFC3339 is a function to asserts that value is a string with date in RFC 3339 format
+// About expression - https://goessner.net/articles/JsonPath/
+func isRFC3339(data []byte, expression string) error {
+	return checkValues(data, expression, rfc3339Check())
+}
+
+func rfc3339Check() valueCheck {
+	return stringCheck("IsRFC3339", func(expression string, value string) error {
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return errors.NewAssertError("IsRFC3339", fmt.Sprintf("on path %v. expect date in RFC 3339, but actual %v", expression, value), value, time.RFC3339)
+		}
+
+		return nil
+	})
+}
+
+// withinDurationOfNow is a function to asserts that value is a date in RFC 3339 format
+// and differs from current time not more than delta
+// About expression - https://goessner.net/articles/JsonPath/
+func withinDurationOfNow(data []byte, expression string, delta time.Duration) error {
+	return checkValues(data, expression, withinDurationOfNowCheck(delta))
+}
+
+func withinDurationOfNowCheck(delta time.Duration) valueCheck {
+	return stringCheck("WithinDurationOfNow", func(expression string, value string) error {
+		date, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return errors.NewAssertError("WithinDurationOfNow", fmt.Sprintf("on path %v. expect date in RFC 3339, but actual %v", expression, value), value, time.RFC3339)
+		}
+
+		now := time.Now()
+
+		if diff := now.Sub(date); diff > delta || diff < -delta {
+			expect := fmt.Sprintf("%v ± %v", now.Format(time.RFC3339), delta)
+
+			return errors.NewAssertError("WithinDurationOfNow", fmt.Sprintf("on path %v. expect %v, but actual %v", expression, expect, value), value, expect)
+		}
+
+		return nil
+	})
+}
+
+func stringCheck(name string, check func(expression string, value string) error) valueCheck {
+	return func(expression string, value interface{}) error {
+		s, ok := value.(string)
+		if !ok {
+			return errors.NewAssertError(name, fmt.Sprintf("on path %v. value %v is not a string", expression, value), value, nil)
+		}
+
+		return check(expression, s)
+	}
+}
+
+// isType is a function to asserts that value has the JSON type
+// About expression - https://goessner.net/articles/JsonPath/
+func isType(data []byte, expression string, expectType string) error {
+	return checkValues(data, expression, typeCheck(expectType))
+}
+
+func typeCheck(expectType string) valueCheck {
+	return func(expression string, value interface{}) error {
+		if actualType := jsonType(value); actualType != expectType {
+			return errors.NewAssertError("IsType", fmt.Sprintf("on path %v. expect type %v, but actual %v", expression, expectType, actualType), actualType, expectType)
+		}
+
+		return nil
+	}
+}
+
+// oneOf is a function to asserts that value is equal to one of the given values
+// About expression - https://goessner.net/articles/JsonPath/
+func oneOf(data []byte, expression string, expect []interface{}) error {
+	return checkValues(data, expression, oneOfCheck(expect))
+}
+
+func oneOfCheck(expect []interface{}) valueCheck {
+	return func(expression string, value interface{}) error {
+		for _, e := range expect {
+			if objectsAreEqual(value, e) {
+				return nil
+			}
+		}
+
+		return errors.NewAssertError("OneOf", fmt.Sprintf("on path %v. expect one of %v, but actual %v", expression, expect, value), value, expect)
+	}
+}
+
+func jsonType(value interface{}) string {
+	if value == nil {
+		return TypeNull
+	}
+
+	switch value.(type) {
+	case string:
+		return TypeString
+	case bool:
+		return TypeBool
+	case map[string]interface{}:
+		return TypeObject
+	case []interface{}:
+		return TypeArray
+	}
+
+	if _, ok := toFloat(value); ok {
+		return TypeNumber
+	}
+
+	return reflect.TypeOf(value).String()
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	v := reflect.ValueOf(value)
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	default:
+		return 0, false
+	}
+}
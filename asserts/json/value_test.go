@@ -0,0 +1,186 @@
+package json
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/cute/errors"
+)
+
+func TestGreaterThan(t *testing.T) {
+	tests := []jsonTest{
+		{
+			caseName:   "greater int",
+			data:       `{"a": 10}`,
+			expression: "$.a",
+			expect:     9.5,
+			IsNilErr:   true,
+		},
+		{
+			caseName:   "all array elements are greater",
+			data:       `{"a": [{"p": 5}, {"p": 7.5}]}`,
+			expression: "$.a[*].p",
+			expect:     4.0,
+			IsNilErr:   true,
+		},
+		{
+			caseName:   "equal",
+			data:       `{"a": 10}`,
+			expression: "$.a",
+			expect:     10.0,
+		},
+		{
+			caseName:   "not number",
+			data:       `{"a": "10"}`,
+			expression: "$.a",
+			expect:     1.0,
+		},
+		{
+			caseName:   "not present",
+			data:       `{"a": 10}`,
+			expression: "$.b",
+			expect:     1.0,
+		},
+	}
+
+	for _, test := range tests {
+		err := GreaterThan(test.expression, test.expect.(float64))([]byte(test.data))
+
+		if test.IsNilErr {
+			require.NoError(t, err, "failed test %v", test.caseName)
+		} else {
+			require.Error(t, err, "failed test %v", test.caseName)
+		}
+	}
+}
+
+func TestLessThan(t *testing.T) {
+	tests := []jsonTest{
+		{
+			caseName:   "less float",
+			data:       `{"a": 1.5}`,
+			expression: "$.a",
+			expect:     2.0,
+			IsNilErr:   true,
+		},
+		{
+			caseName:   "negative",
+			data:       `{"a": -1}`,
+			expression: "$.a",
+			expect:     0.0,
+			IsNilErr:   true,
+		},
+		{
+			caseName:   "greater",
+			data:       `{"a": 3}`,
+			expression: "$.a",
+			expect:     2.0,
+		},
+	}
+
+	for _, test := range tests {
+		err := LessThan(test.expression, test.expect.(float64))([]byte(test.data))
+
+		if test.IsNilErr {
+			require.NoError(t, err, "failed test %v", test.caseName)
+		} else {
+			require.Error(t, err, "failed test %v", test.caseName)
+		}
+	}
+}
+
+func TestBetween(t *testing.T) {
+	require.NoError(t, Between("$.a", 1, 10)([]byte(`{"a": 1}`)))
+	require.NoError(t, Between("$.a", 1, 10)([]byte(`{"a": 10}`)))
+	require.NoError(t, Between("$.a[*]", 1, 10)([]byte(`{"a": [2, 5.5, 9]}`)))
+	require.Error(t, Between("$.a[*]", 1, 10)([]byte(`{"a": [2, 11]}`)))
+	require.Error(t, Between("$.a", 1, 10)([]byte(`{"a": 0.99}`)))
+
+	err := Between("$.a", 1, 10)([]byte(`{"a": 11}`))
+	require.Error(t, err)
+
+	fields := err.(errors.WithFields).GetFields()
+	require.Equal(t, int64(11), fields[errors.ActualField])
+	require.Equal(t, "[1, 10]", fields[errors.ExpectedField])
+}
+
+func TestInDelta(t *testing.T) {
+	require.NoError(t, InDelta("$.a", 0.3, 1e-9)([]byte(`{"a": 0.30000000000000004}`)))
+	require.NoError(t, InDelta("$.a", 10, 0.5)([]byte(`{"a": 10.5}`)))
+	require.Error(t, InDelta("$.a", 10, 0.5)([]byte(`{"a": 10.51}`)))
+	require.Error(t, InDelta("$.a", 10, 0.5)([]byte(`{"a": "10"}`)))
+}
+
+func TestMatches(t *testing.T) {
+	require.NoError(t, Matches("$.a", `^\d{3}-\d{2}$`)([]byte(`{"a": "123-45"}`)))
+	require.Error(t, Matches("$.a", `^\d{3}-\d{2}$`)([]byte(`{"a": "123-456"}`)))
+	require.Error(t, Matches("$.a", `^\d+$`)([]byte(`{"a": 123}`)))
+
+	err := Matches("$.a", `(`)([]byte(`{"a": "123"}`))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "could not compile pattern")
+}
+
+func TestIsType(t *testing.T) {
+	data := []byte(`{"s": "str", "i": 1, "f": 1.5, "b": false, "o": {}, "a": [], "n": null}`)
+
+	for expression, expectType := range map[string]string{
+		"$.s": TypeString,
+		"$.i": TypeNumber,
+		"$.f": TypeNumber,
+		"$.b": TypeBool,
+		"$.o": TypeObject,
+		"$.a": TypeArray,
+		"$.n": TypeNull,
+	} {
+		require.NoError(t, IsType(expression, expectType)(data), "failed test %v", expression)
+	}
+
+	err := IsType("$.s", TypeNumber)(data)
+	require.Error(t, err)
+
+	fields := err.(errors.WithFields).GetFields()
+	require.Equal(t, TypeString, fields[errors.ActualField])
+	require.Equal(t, TypeNumber, fields[errors.ExpectedField])
+}
+
+func TestOneOf(t *testing.T) {
+	require.NoError(t, OneOf("$.status", "new", "done")([]byte(`{"status": "done"}`)))
+	require.NoError(t, OneOf("$.code", 1, 2, 3)([]byte(`{"code": 2}`)))
+	require.NoError(t, OneOf("$.items[*].status", "new", "done")([]byte(`{"items": [{"status": "new"}, {"status": "done"}]}`)))
+	require.Error(t, OneOf("$.status", "new", "done")([]byte(`{"status": "failed"}`)))
+	require.Error(t, OneOf("$.status")([]byte(`{"status": "failed"}`)))
+}
+
+func TestIsUUID(t *testing.T) {
+	require.NoError(t, IsUUID("$.id")([]byte(`{"id": "3f2504e0-4f89-11d3-9a0c-0305e82c3301"}`)))
+	require.Error(t, IsUUID("$.id")([]byte(`{"id": "3f2504e0-4f89-11d3-9a0c"}`)))
+	require.Error(t, IsUUID("$.id")([]byte(`{"id": 1}`)))
+}
+
+func TestIsEmail(t *testing.T) {
+	require.NoError(t, IsEmail("$.email")([]byte(`{"email": "user@example.com"}`)))
+	require.Error(t, IsEmail("$.email")([]byte(`{"email": "user.example.com"}`)))
+	require.Error(t, IsEmail("$.email")([]byte(`{"email": "User <user@example.com>"}`)))
+}
+
+func TestIsRFC3339(t *testing.T) {
+	require.NoError(t, IsRFC3339("$.date")([]byte(`{"date": "2024-01-02T15:04:05Z"}`)))
+	require.NoError(t, IsRFC3339("$.date")([]byte(`{"date": "2024-01-02T15:04:05.123+03:00"}`)))
+	require.Error(t, IsRFC3339("$.date")([]byte(`{"date": "2024-01-02 15:04:05"}`)))
+}
+
+func TestWithinDurationOfNow(t *testing.T) {
+	data := func(date time.Time) []byte {
+		return []byte(fmt.Sprintf(`{"date": %q}`, date.Format(time.RFC3339)))
+	}
+
+	require.NoError(t, WithinDurationOfNow("$.date", time.Minute)(data(time.Now())))
+	require.NoError(t, WithinDurationOfNow("$.date", time.Minute)(data(time.Now().Add(30*time.Second))))
+	require.Error(t, WithinDurationOfNow("$.date", time.Minute)(data(time.Now().Add(-time.Hour))))
+	require.Error(t, WithinDurationOfNow("$.date", time.Minute)(data(time.Now().Add(time.Hour))))
+	require.Error(t, WithinDurationOfNow("$.date", time.Minute)([]byte(`{"date": "yesterday"}`)))
+}
@@ -0,0 +1,263 @@
+package prometheus
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Labels is a set of label pairs, which are used for select metric samples
+// Sample matches Labels, if it contains all pairs from Labels
+type Labels map[string]string
+
+// Sample is one line of prometheus text exposition format
+type Sample struct {
+	Name   string
+	Labels Labels
+	Value  float64
+}
+
+// Metrics is a result of parsing prometheus text exposition format
+type Metrics struct {
+	// Samples in order of appearance
+	Samples []*Sample
+	// Types contains metric types from # TYPE lines
+	Types map[string]string
+	// Help contains metric descriptions from # HELP lines
+	Help map[string]string
+}
+
+// Parse is a function for parse prometheus text exposition format
+// About format - https://prometheus.io/docs/instrumenting/exposition_formats/
+func Parse(data []byte) (*Metrics, error) {
+	var (
+		metrics = &Metrics{
+			Samples: make([]*Sample, 0),
+			Types:   make(map[string]string),
+			Help:    make(map[string]string),
+		}
+		scanner = bufio.NewScanner(bytes.NewReader(data))
+		lineNum = 0
+	)
+
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		lineNum++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "#") {
+			parseComment(metrics, line)
+
+			continue
+		}
+
+		sample, err := parseSample(line)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse metrics on line %v: %w", lineNum, err)
+		}
+
+		metrics.Samples = append(metrics.Samples, sample)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read metrics: %w", err)
+	}
+
+	return metrics, nil
+}
+
+// Find is a function for get all samples with name, which match labels
+func (m *Metrics) Find(name string, labels Labels) []*Sample {
+	res := make([]*Sample, 0)
+
+	for _, sample := range m.Samples {
+		if sample.Name == name && sample.matches(labels) {
+			res = append(res, sample)
+		}
+	}
+
+	return res
+}
+
+// Sum is a function for get sum of values of all samples with name, which match labels
+// Second value is false, if there are no such samples
+func (m *Metrics) Sum(name string, labels Labels) (float64, bool) {
+	samples := m.Find(name, labels)
+	if len(samples) == 0 {
+		return 0, false
+	}
+
+	sum := 0.0
+	for _, sample := range samples {
+		sum += sample.Value
+	}
+
+	return sum, true
+}
+
+func (s *Sample) matches(labels Labels) bool {
+	for k, v := range labels {
+		if actual, ok := s.Labels[k]; !ok || actual != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String returns sample in exposition format without timestamp
+func (s *Sample) String() string {
+	return s.Name + s.Labels.String() + " " + strconv.FormatFloat(s.Value, 'g', -1, 64)
+}
+
+// String returns labels in exposition format, e.g. {code="200",method="get"}
+func (l Labels) String() string {
+	if len(l) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(l))
+	for k := range l {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", k, l[k]))
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func parseComment(metrics *Metrics, line string) {
+	fields := strings.SplitN(strings.TrimSpace(strings.TrimPrefix(line, "#")), " ", 3)
+	if len(fields) < 3 {
+		return
+	}
+
+	switch fields[0] {
+	case "TYPE":
+		metrics.Types[fields[1]] = strings.TrimSpace(fields[2])
+	case "HELP":
+		metrics.Help[fields[1]] = fields[2]
+	}
+}
+
+func parseSample(line string) (*Sample, error) {
+	var (
+		sample = &Sample{Labels: make(Labels)}
+		rest   string
+		err    error
+	)
+
+	nameEnd := strings.IndexAny(line, "{ \t")
+	if nameEnd == -1 {
+		return nil, fmt.Errorf("sample %q has no value", line)
+	}
+
+	sample.Name = line[:nameEnd]
+	rest = line[nameEnd:]
+
+	if strings.HasPrefix(rest, "{") {
+		rest, err = parseLabels(rest[1:], sample.Labels)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("sample %q has no value", line)
+	}
+
+	sample.Value, err = parseValue(fields[0])
+	if err != nil {
+		return nil, fmt.Errorf("sample %q has invalid value: %w", line, err)
+	}
+
+	return sample, nil
+}
+
+// parseLabels parses labels after open brace and returns rest of line after close brace
+func parseLabels(s string, labels Labels) (string, error) {
+	for {
+		s = strings.TrimLeft(s, " \t,")
+
+		if strings.HasPrefix(s, "}") {
+			return s[1:], nil
+		}
+
+		eq := strings.Index(s, "=")
+		if eq == -1 {
+			return "", fmt.Errorf("invalid labels %q", s)
+		}
+
+		name := strings.TrimSpace(s[:eq])
+		s = strings.TrimLeft(s[eq+1:], " \t")
+
+		if !strings.HasPrefix(s, `"`) {
+			return "", fmt.Errorf("label %v value must be quoted", name)
+		}
+
+		value, n, err := unquoteLabelValue(s[1:])
+		if err != nil {
+			return "", fmt.Errorf("label %v: %w", name, err)
+		}
+
+		labels[name] = value
+		s = s[1+n:]
+	}
+}
+
+// unquoteLabelValue reads escaped value until closing quote and returns count of consumed bytes
+func unquoteLabelValue(s string) (string, int, error) {
+	var b strings.Builder
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"':
+			return b.String(), i + 1, nil
+		case '\\':
+			if i+1 == len(s) {
+				return "", 0, fmt.Errorf("unexpected end of label value")
+			}
+
+			i++
+
+			switch s[i] {
+			case 'n':
+				b.WriteByte('\n')
+			default:
+				b.WriteByte(s[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return "", 0, fmt.Errorf("label value is not closed")
+}
+
+func parseValue(s string) (float64, error) {
+	switch s {
+	case "+Inf", "Inf":
+		return math.Inf(1), nil
+	case "-Inf":
+		return math.Inf(-1), nil
+	case "NaN":
+		return math.NaN(), nil
+	}
+
+	return strconv.ParseFloat(s, 64)
+}
@@ -0,0 +1,260 @@
+package prometheus
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ozontech/cute"
+	"github.com/ozontech/cute/errors"
+)
+
+// Snapshot is a storage for metrics from one scrape.
+// It is used for compare metrics between steps of one test, for example:
+//
+//	before := prometheus.NewSnapshot()
+//	// first step: scrape /metrics
+//	AssertBody(before.Capture())
+//	// second step: do business request
+//	// third step: scrape /metrics again
+//	AssertBody(prometheus.Delta(before, "orders_total", prometheus.Labels{"status": "ok"}, 1))
+type Snapshot struct {
+	mu      sync.RWMutex
+	metrics *Metrics
+}
+
+// NewSnapshot is a function for create empty Snapshot
+func NewSnapshot() *Snapshot {
+	return &Snapshot{}
+}
+
+// Capture is a function to parse metrics from body and save it to snapshot
+func (s *Snapshot) Capture() cute.AssertBody {
+	return func(body []byte) error {
+		metrics, err := Parse(body)
+		if err != nil {
+			return err
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.metrics = metrics
+
+		return nil
+	}
+}
+
+// Metrics is a function for get captured metrics
+// Returns nil, if snapshot was not captured
+func (s *Snapshot) Metrics() *Metrics {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.metrics
+}
+
+// Present is a function to assert that metric with labels is present
+func Present(name string, labels Labels) cute.AssertBody {
+	return func(body []byte) error {
+		return present(body, name, labels)
+	}
+}
+
+// NotPresent is a function to assert that metric with labels is not present
+func NotPresent(name string, labels Labels) cute.AssertBody {
+	return func(body []byte) error {
+		return notPresent(body, name, labels)
+	}
+}
+
+// HasLabels is a function to assert that every sample of metric has all given label names
+func HasLabels(name string, labelNames ...string) cute.AssertBody {
+	return func(body []byte) error {
+		return hasLabels(body, name, labelNames)
+	}
+}
+
+// Equal is a function to assert that value of metric with labels is equal to expect
+// If labels match several samples, their values will be summed
+func Equal(name string, labels Labels, expect float64) cute.AssertBody {
+	return func(body []byte) error {
+		return equal(body, name, labels, expect)
+	}
+}
+
+// GreaterThan is a function to assert that value of metric with labels is greater than minimum
+// If labels match several samples, their values will be summed
+func GreaterThan(name string, labels Labels, minimum float64) cute.AssertBody {
+	return func(body []byte) error {
+		return greaterThan(body, name, labels, minimum)
+	}
+}
+
+// LessThan is a function to assert that value of metric with labels is less than maximum
+// If labels match several samples, their values will be summed
+func LessThan(name string, labels Labels, maximum float64) cute.AssertBody {
+	return func(body []byte) error {
+		return lessThan(body, name, labels, maximum)
+	}
+}
+
+// Delta is a function to assert that value of metric with labels changed exactly by expect since snapshot
+// If metric is not present in snapshot, previous value is 0
+func Delta(snapshot *Snapshot, name string, labels Labels, expect float64) cute.AssertBody {
+	return func(body []byte) error {
+		return delta(body, snapshot, name, labels, expect)
+	}
+}
+
+// DeltaGreaterThan is a function to assert that value of metric with labels increased more than minimum since snapshot
+// If metric is not present in snapshot, previous value is 0
+func DeltaGreaterThan(snapshot *Snapshot, name string, labels Labels, minimum float64) cute.AssertBody {
+	return func(body []byte) error {
+		return deltaGreaterThan(body, snapshot, name, labels, minimum)
+	}
+}
+
+// GetValue is function for get sum of values of metric with labels from body
+func GetValue(body []byte, name string, labels Labels) (float64, error) {
+	metrics, err := Parse(body)
+	if err != nil {
+		return 0, err
+	}
+
+	value, ok := metrics.Sum(name, labels)
+	if !ok {
+		return 0, fmt.Errorf("could not find metric %v%v", name, labels)
+	}
+
+	return value, nil
+}
+
+func present(data []byte, name string, labels Labels) error {
+	metrics, err := Parse(data)
+	if err != nil {
+		return err
+	}
+
+	if len(metrics.Find(name, labels)) == 0 {
+		return errors.NewAssertError("Present", fmt.Sprintf("metric %v%v is not present", name, labels), nil, nil)
+	}
+
+	return nil
+}
+
+func notPresent(data []byte, name string, labels Labels) error {
+	metrics, err := Parse(data)
+	if err != nil {
+		return err
+	}
+
+	if samples := metrics.Find(name, labels); len(samples) != 0 {
+		return errors.NewAssertError("NotPresent", fmt.Sprintf("metric %v%v is present", name, labels), samples[0].String(), nil)
+	}
+
+	return nil
+}
+
+func hasLabels(data []byte, name string, labelNames []string) error {
+	metrics, err := Parse(data)
+	if err != nil {
+		return err
+	}
+
+	samples := metrics.Find(name, nil)
+	if len(samples) == 0 {
+		return errors.NewAssertError("HasLabels", fmt.Sprintf("metric %v is not present", name), nil, labelNames)
+	}
+
+	for _, sample := range samples {
+		for _, labelName := range labelNames {
+			if _, ok := sample.Labels[labelName]; !ok {
+				return errors.NewAssertError("HasLabels", fmt.Sprintf("sample %v has no label %v", sample, labelName), sample.Labels.String(), labelNames)
+			}
+		}
+	}
+
+	return nil
+}
+
+func equal(data []byte, name string, labels Labels, expect float64) error {
+	value, err := GetValue(data, name, labels)
+	if err != nil {
+		return err
+	}
+
+	if value != expect {
+		return errors.NewAssertError("Equal", fmt.Sprintf("metric %v%v. expect %v, but actual %v", name, labels, expect, value), value, expect)
+	}
+
+	return nil
+}
+
+func greaterThan(data []byte, name string, labels Labels, minimum float64) error {
+	value, err := GetValue(data, name, labels)
+	if err != nil {
+		return err
+	}
+
+	if value <= minimum {
+		return errors.NewAssertError("GreaterThan", fmt.Sprintf("metric %v%v. expect greater than %v, but actual %v", name, labels, minimum, value), value, minimum)
+	}
+
+	return nil
+}
+
+func lessThan(data []byte, name string, labels Labels, maximum float64) error {
+	value, err := GetValue(data, name, labels)
+	if err != nil {
+		return err
+	}
+
+	if value >= maximum {
+		return errors.NewAssertError("LessThan", fmt.Sprintf("metric %v%v. expect less than %v, but actual %v", name, labels, maximum, value), value, maximum)
+	}
+
+	return nil
+}
+
+func getDelta(data []byte, snapshot *Snapshot, name string, labels Labels) (float64, error) {
+	before := snapshot.Metrics()
+	if before == nil {
+		return 0, fmt.Errorf("snapshot for metric %v%v was not captured", name, labels)
+	}
+
+	value, err := GetValue(data, name, labels)
+	if err != nil {
+		return 0, err
+	}
+
+	// metric could be created after snapshot, so previous value is 0
+	previous, _ := before.Sum(name, labels)
+
+	return value - previous, nil
+}
+
+func delta(data []byte, snapshot *Snapshot, name string, labels Labels, expect float64) error {
+	diff, err := getDelta(data, snapshot, name, labels)
+	if err != nil {
+		return err
+	}
+
+	if diff != expect {
+		return errors.NewAssertError("Delta", fmt.Sprintf("metric %v%v. expect delta %v, but actual %v", name, labels, expect, diff), diff, expect)
+	}
+
+	return nil
+}
+
+func deltaGreaterThan(data []byte, snapshot *Snapshot, name string, labels Labels, minimum float64) error {
+	diff, err := getDelta(data, snapshot, name, labels)
+	if err != nil {
+		return err
+	}
+
+	if diff <= minimum {
+		return errors.NewAssertError("DeltaGreaterThan", fmt.Sprintf("metric %v%v. expect delta greater than %v, but actual %v", name, labels, minimum, diff), diff, minimum)
+	}
+
+	return nil
+}
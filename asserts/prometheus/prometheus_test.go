@@ -0,0 +1,105 @@
+package prometheus
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const scrape = `# HELP http_requests_total The total number of HTTP requests.
+# TYPE http_requests_total counter
+http_requests_total{method="post",code="200"} 1027 1395066363000
+http_requests_total{method="post",code="400"}    3 1395066363000
+http_requests_total{method="get",code="200"} 10
+
+# Escaping in label values:
+msdos_file_access_time_seconds{path="C:\\DIR\\FILE.TXT",error="Cannot find file:\n\"FILE.TXT\""} 1.458255915e9
+
+# Minimalistic line:
+metric_without_timestamp_and_labels 12.47
+
+# A weird metric from before the epoch:
+something_weird{problem="division by zero"} +Inf -3982045
+`
+
+func TestParse(t *testing.T) {
+	metrics, err := Parse([]byte(scrape))
+	require.NoError(t, err)
+
+	require.Len(t, metrics.Samples, 6)
+	require.Equal(t, "counter", metrics.Types["http_requests_total"])
+	require.Equal(t, "The total number of HTTP requests.", metrics.Help["http_requests_total"])
+
+	samples := metrics.Find("msdos_file_access_time_seconds", nil)
+	require.Len(t, samples, 1)
+	require.Equal(t, `C:\DIR\FILE.TXT`, samples[0].Labels["path"])
+	require.Equal(t, "Cannot find file:\n\"FILE.TXT\"", samples[0].Labels["error"])
+
+	weird := metrics.Find("something_weird", Labels{"problem": "division by zero"})
+	require.Len(t, weird, 1)
+	require.True(t, math.IsInf(weird[0].Value, 1))
+
+	sum, ok := metrics.Sum("http_requests_total", Labels{"method": "post"})
+	require.True(t, ok)
+	require.Equal(t, 1030.0, sum)
+}
+
+func TestParseError(t *testing.T) {
+	_, err := Parse([]byte(`metric{label=value} 1`))
+	require.Error(t, err)
+
+	_, err = Parse([]byte(`metric_without_value`))
+	require.Error(t, err)
+
+	_, err = Parse([]byte(`metric{label="value"} abc`))
+	require.Error(t, err)
+}
+
+func TestPresent(t *testing.T) {
+	body := []byte(scrape)
+
+	require.NoError(t, Present("http_requests_total", Labels{"code": "400"})(body))
+	require.Error(t, Present("http_requests_total", Labels{"code": "500"})(body))
+	require.NoError(t, NotPresent("http_requests_total", Labels{"code": "500"})(body))
+	require.Error(t, NotPresent("metric_without_timestamp_and_labels", nil)(body))
+}
+
+func TestHasLabels(t *testing.T) {
+	body := []byte(scrape)
+
+	require.NoError(t, HasLabels("http_requests_total", "method", "code")(body))
+	require.Error(t, HasLabels("http_requests_total", "handler")(body))
+	require.Error(t, HasLabels("unknown_metric", "code")(body))
+}
+
+func TestValues(t *testing.T) {
+	body := []byte(scrape)
+
+	require.NoError(t, Equal("http_requests_total", Labels{"method": "get"}, 10)(body))
+	require.Error(t, Equal("http_requests_total", Labels{"method": "get"}, 11)(body))
+	require.NoError(t, Equal("http_requests_total", nil, 1040)(body))
+	require.NoError(t, GreaterThan("metric_without_timestamp_and_labels", nil, 12)(body))
+	require.Error(t, GreaterThan("metric_without_timestamp_and_labels", nil, 13)(body))
+	require.NoError(t, LessThan("metric_without_timestamp_and_labels", nil, 13)(body))
+	require.Error(t, LessThan("metric_without_timestamp_and_labels", nil, 12)(body))
+	require.Error(t, Equal("unknown_metric", nil, 0)(body))
+}
+
+func TestDelta(t *testing.T) {
+	var (
+		snapshot = NewSnapshot()
+		before   = []byte(`orders_total{status="ok"} 5`)
+		after    = []byte("orders_total{status=\"ok\"} 6\norders_total{status=\"failed\"} 2")
+	)
+
+	require.Error(t, Delta(snapshot, "orders_total", nil, 1)(after), "snapshot is not captured")
+
+	require.NoError(t, snapshot.Capture()(before))
+
+	require.NoError(t, Delta(snapshot, "orders_total", Labels{"status": "ok"}, 1)(after))
+	require.Error(t, Delta(snapshot, "orders_total", Labels{"status": "ok"}, 2)(after))
+	require.NoError(t, Delta(snapshot, "orders_total", Labels{"status": "failed"}, 2)(after))
+	require.NoError(t, DeltaGreaterThan(snapshot, "orders_total", nil, 2)(after))
+	require.Error(t, DeltaGreaterThan(snapshot, "orders_total", nil, 3)(after))
+}
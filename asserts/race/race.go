@@ -0,0 +1,143 @@
+package race
+
+import (
+	stdErrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+
+	"github.com/ozontech/cute"
+	"github.com/ozontech/cute/asserts/json"
+	"github.com/ozontech/cute/errors"
+	"github.com/ozontech/cute/internal/utils"
+)
+
+var errBodyTruncated = stdErrors.New("body is truncated by max body size")
+
+// StatusCount is a function to asserts that exactly count responses have status code
+func StatusCount(code, count int) cute.AssertRace {
+	return func(responses []*http.Response) error {
+		actual := 0
+
+		for _, resp := range responses {
+			if resp != nil && resp.StatusCode == code {
+				actual++
+			}
+		}
+
+		if actual != count {
+			return errors.NewAssertError("StatusCount", fmt.Sprintf("expected %v responses with status %v, but was %v, statuses %v", count, code, actual, statuses(responses)), actual, count)
+		}
+
+		return nil
+	}
+}
+
+// StatusOneOf is a function to asserts that all responses have one of status codes
+func StatusOneOf(codes ...int) cute.AssertRace {
+	return func(responses []*http.Response) error {
+		for i, resp := range responses {
+			if resp == nil {
+				return errors.NewAssertError("StatusOneOf", fmt.Sprintf("response %v is not received", i+1), nil, codes)
+			}
+
+			if !containsCode(codes, resp.StatusCode) {
+				return errors.NewAssertError("StatusOneOf", fmt.Sprintf("response %v has status %v, expected one of %v", i+1, resp.StatusCode, codes), resp.StatusCode, codes)
+			}
+		}
+
+		return nil
+	}
+}
+
+// JSONEqual is a function to asserts that jsonpath expression value is the same in responses
+// If codes are set, only responses with these status codes are compared, for example only 201 and 200.
+func JSONEqual(expression string, codes ...int) cute.AssertRace {
+	return func(responses []*http.Response) error {
+		var (
+			first    interface{}
+			hasFirst bool
+		)
+
+		for i, resp := range responses {
+			if resp == nil || (len(codes) != 0 && !containsCode(codes, resp.StatusCode)) {
+				continue
+			}
+
+			body, err := readBody(resp)
+			if err != nil {
+				return fmt.Errorf("could not read body of response %v error: '%s'", i+1, err)
+			}
+
+			values, err := json.GetValueFromJSON(body, expression)
+			if err != nil {
+				return errors.NewAssertError("JSONEqual", fmt.Sprintf("response %v: %v", i+1, err), nil, nil)
+			}
+
+			value := values[0]
+			if len(values) > 1 {
+				value = values
+			}
+
+			if !hasFirst {
+				first, hasFirst = value, true
+
+				continue
+			}
+
+			if !reflect.DeepEqual(first, value) {
+				return errors.NewAssertError("JSONEqual", fmt.Sprintf("on path %v response %v has %v, but first response has %v", expression, i+1, value, first), value, first)
+			}
+		}
+
+		return nil
+	}
+}
+
+// readBody returns body of response, which is decoded by Content-Encoding
+// Body, which is truncated by max body size of cute, could not be compared, so error is returned.
+func readBody(resp *http.Response) ([]byte, error) {
+	if body, ok := resp.Body.(interface{ Truncated() bool }); ok && body.Truncated() {
+		return nil, errBodyTruncated
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	contentEncoding := resp.Header.Get("Content-Encoding")
+	if contentEncoding == "" || len(body) == 0 {
+		return body, nil
+	}
+
+	decoded, err := utils.DecodeBody(contentEncoding, body)
+	if stdErrors.Is(err, utils.ErrUnsupportedEncoding) {
+		return body, nil
+	}
+
+	return decoded, err
+}
+
+func containsCode(codes []int, code int) bool {
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+func statuses(responses []*http.Response) []int {
+	res := make([]int, 0, len(responses))
+
+	for _, resp := range responses {
+		if resp != nil {
+			res = append(res, resp.StatusCode)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,69 @@
+package race
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/cute/internal/utils"
+)
+
+func newResponse(code int, body string) *http.Response {
+	return &http.Response{StatusCode: code, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+// truncatedBody is a body, which is truncated by max body size of cute
+type truncatedBody struct {
+	io.ReadCloser
+}
+
+func (truncatedBody) Truncated() bool {
+	return true
+}
+
+func TestStatusCount(t *testing.T) {
+	responses := []*http.Response{newResponse(201, ""), newResponse(409, ""), newResponse(409, ""), nil}
+
+	require.NoError(t, StatusCount(201, 1)(responses))
+	require.NoError(t, StatusCount(409, 2)(responses))
+	require.EqualError(t, StatusCount(201, 2)(responses), "expected 2 responses with status 201, but was 1, statuses [201 409 409]")
+}
+
+func TestStatusOneOf(t *testing.T) {
+	require.NoError(t, StatusOneOf(201, 409)([]*http.Response{newResponse(201, ""), newResponse(409, "")}))
+	require.EqualError(t, StatusOneOf(201, 409)([]*http.Response{newResponse(201, ""), newResponse(500, "")}), "response 2 has status 500, expected one of [201 409]")
+	require.EqualError(t, StatusOneOf(201)([]*http.Response{nil}), "response 1 is not received")
+}
+
+func TestJSONEqual(t *testing.T) {
+	responses := func() []*http.Response {
+		return []*http.Response{
+			newResponse(201, `{"id":"a"}`),
+			newResponse(409, `{"error":"conflict"}`),
+			newResponse(200, `{"id":"a"}`),
+		}
+	}
+
+	require.NoError(t, JSONEqual("$.id", 200, 201)(responses()))
+	require.EqualError(t, JSONEqual("$.id")(responses()), "response 2: could not find element by path $.id in JSON")
+
+	// Encoded bodies are compared after decoding
+	encoded := newResponse(201, "")
+	gzipped, err := utils.EncodeBody("gzip", []byte(`{"id":"a"}`))
+	require.NoError(t, err)
+
+	encoded.Header = http.Header{"Content-Encoding": []string{"gzip"}}
+	encoded.Body = io.NopCloser(bytes.NewReader(gzipped))
+	require.NoError(t, JSONEqual("$.id")([]*http.Response{newResponse(201, `{"id":"a"}`), encoded}))
+
+	truncated := newResponse(201, `{"id":`)
+	truncated.Body = truncatedBody{ReadCloser: truncated.Body}
+	require.EqualError(t, JSONEqual("$.id")([]*http.Response{newResponse(201, `{"id":"a"}`), truncated}), "could not read body of response 2 error: 'body is truncated by max body size'")
+
+	different := []*http.Response{newResponse(201, `{"id":"a"}`), newResponse(201, `{"id":"b"}`)}
+	require.EqualError(t, JSONEqual("$.id")(different), "on path $.id response 2 has b, but first response has a")
+}
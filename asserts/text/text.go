@@ -0,0 +1,119 @@
+package text
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/ozontech/cute"
+)
+
+// Equal is a function to assert that body is equal to the given text
+// If body is not equal, unified diff will be attached to allure step
+func Equal(expect string) cute.AssertBody {
+	return func(body []byte) error {
+		return equal(body, expect)
+	}
+}
+
+// NotEqual is a function to assert that body is not equal to the given text
+func NotEqual(expect string) cute.AssertBody {
+	return func(body []byte) error {
+		return notEqual(body, expect)
+	}
+}
+
+// Contains is a function to assert that body contains the given substring
+func Contains(substr string) cute.AssertBody {
+	return func(body []byte) error {
+		return contains(body, substr)
+	}
+}
+
+// NotContains is a function to assert that body does not contain the given substring
+func NotContains(substr string) cute.AssertBody {
+	return func(body []byte) error {
+		return notContains(body, substr)
+	}
+}
+
+// HasPrefix is a function to assert that body begins with the given prefix
+func HasPrefix(prefix string) cute.AssertBody {
+	return func(body []byte) error {
+		return hasPrefix(body, prefix)
+	}
+}
+
+// HasSuffix is a function to assert that body ends with the given suffix
+func HasSuffix(suffix string) cute.AssertBody {
+	return func(body []byte) error {
+		return hasSuffix(body, suffix)
+	}
+}
+
+// Matches is a function to assert that body matches the given regular expression
+// About syntax - https://github.com/google/re2/wiki/Syntax
+func Matches(pattern string) cute.AssertBody {
+	return func(body []byte) error {
+		return matches(body, pattern)
+	}
+}
+
+// NotMatches is a function to assert that body does not match the given regular expression
+// About syntax - https://github.com/google/re2/wiki/Syntax
+func NotMatches(pattern string) cute.AssertBody {
+	return func(body []byte) error {
+		return notMatches(body, pattern)
+	}
+}
+
+// LineCount is a function to assert that body has the expected count of lines
+// Trailing line break is not counted as a separate line
+func LineCount(expectCount int) cute.AssertBody {
+	return func(body []byte) error {
+		return lineCount(body, expectCount)
+	}
+}
+
+// Capture is a function to extract named groups of the regular expression into values
+// For example, pattern `version: (?P<version>\S+)` will put found version to values["version"]
+// If body does not match the pattern, assert will fail
+// About syntax - https://github.com/google/re2/wiki/Syntax
+func Capture(pattern string, values map[string]string) cute.AssertBody {
+	return func(body []byte) error {
+		found, err := GetNamedValues(body, pattern)
+		if err != nil {
+			return err
+		}
+
+		for name, value := range found {
+			values[name] = value
+		}
+
+		return nil
+	}
+}
+
+// GetNamedValues is function for get values of named groups of the regular expression from body
+func GetNamedValues(body []byte, pattern string) (map[string]string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("could not compile pattern in GetNamedValues error: '%s'", err)
+	}
+
+	match := re.FindSubmatch(body)
+	if match == nil {
+		return nil, fmt.Errorf("could not find match by pattern %v in body", pattern)
+	}
+
+	res := make(map[string]string)
+
+	for i, name := range re.SubexpNames() {
+		if i == 0 || name == "" {
+			continue
+		}
+
+		res[name] = string(match[i])
+	}
+
+	return res, nil
+}
@@ -0,0 +1,138 @@
+package text
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	cuteErrors "github.com/ozontech/cute/errors"
+)
+
+type textTest struct {
+	caseName string
+	data     string
+	expect   string
+	IsNilErr bool
+}
+
+func TestEqual(t *testing.T) {
+	tests := []textTest{
+		{
+			caseName: "same text",
+			data:     "ok\n",
+			expect:   "ok\n",
+			IsNilErr: true,
+		},
+		{
+			caseName: "different text",
+			data:     "status: down\n",
+			expect:   "status: up\n",
+		},
+	}
+
+	for _, test := range tests {
+		err := Equal(test.expect)([]byte(test.data))
+
+		if test.IsNilErr {
+			require.NoError(t, err, "failed test %v", test.caseName)
+		} else {
+			require.Error(t, err, "failed test %v", test.caseName)
+		}
+	}
+}
+
+func TestEqualDiffAttachment(t *testing.T) {
+	err := Equal("a\nb\nc\n")([]byte("a\nx\nc\n"))
+	require.Error(t, err)
+
+	withAttachments, ok := err.(cuteErrors.WithAttachments)
+	require.True(t, ok)
+	require.Len(t, withAttachments.GetAttachments(), 1)
+
+	diff := string(withAttachments.GetAttachments()[0].Content)
+	require.Contains(t, diff, "-b")
+	require.Contains(t, diff, "+x")
+}
+
+func TestNotEqual(t *testing.T) {
+	require.NoError(t, NotEqual("a")([]byte("b")))
+	require.Error(t, NotEqual("a")([]byte("a")))
+}
+
+func TestContains(t *testing.T) {
+	tests := []textTest{
+		{
+			caseName: "contains",
+			data:     "# HELP up\nup 1\n",
+			expect:   "up 1",
+			IsNilErr: true,
+		},
+		{
+			caseName: "not contains",
+			data:     "# HELP up\nup 0\n",
+			expect:   "up 1",
+		},
+	}
+
+	for _, test := range tests {
+		err := Contains(test.expect)([]byte(test.data))
+
+		if test.IsNilErr {
+			require.NoError(t, err, "failed test %v", test.caseName)
+		} else {
+			require.Error(t, err, "failed test %v", test.caseName)
+		}
+	}
+}
+
+func TestNotContains(t *testing.T) {
+	require.NoError(t, NotContains("error")([]byte("all good")))
+	require.Error(t, NotContains("error")([]byte("internal error")))
+}
+
+func TestHasPrefixSuffix(t *testing.T) {
+	body := []byte("id,name\n1,cute\n")
+
+	require.NoError(t, HasPrefix("id,name")(body))
+	require.Error(t, HasPrefix("name")(body))
+	require.NoError(t, HasSuffix("cute\n")(body))
+	require.Error(t, HasSuffix("id")(body))
+}
+
+func TestMatches(t *testing.T) {
+	require.NoError(t, Matches(`^OK\s*$`)([]byte("OK\n")))
+	require.Error(t, Matches(`^OK$`)([]byte("FAIL")))
+	require.Error(t, Matches(`(`)([]byte("FAIL")))
+	require.NoError(t, NotMatches(`(?i)error`)([]byte("OK")))
+	require.Error(t, NotMatches(`(?i)error`)([]byte("Error")))
+}
+
+func TestLineCount(t *testing.T) {
+	tests := []struct {
+		data  string
+		count int
+	}{
+		{data: "", count: 0},
+		{data: "one", count: 1},
+		{data: "one\n", count: 1},
+		{data: "one\ntwo", count: 2},
+		{data: "id,name\n1,a\n2,b\n", count: 3},
+	}
+
+	for _, test := range tests {
+		require.NoError(t, LineCount(test.count)([]byte(test.data)), "failed test %q", test.data)
+	}
+
+	require.Error(t, LineCount(2)([]byte("one\n")))
+}
+
+func TestCapture(t *testing.T) {
+	values := make(map[string]string)
+
+	err := Capture(`version: (?P<version>\S+) build: (?P<build>\d+)`, values)([]byte("version: 1.2.3 build: 42"))
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"version": "1.2.3", "build": "42"}, values)
+
+	err = Capture(`version: (?P<version>\S+)`, values)([]byte("unknown"))
+	require.Error(t, err)
+}
@@ -0,0 +1,139 @@
+package text
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/ozontech/cute/errors"
+)
+
+// equal is a function to assert that body is equal to the given text
+func equal(data []byte, expect string) error {
+	actual := string(data)
+
+	if actual == expect {
+		return nil
+	}
+
+	cErr := errors.NewEmptyAssertError("Equal", "text is not the same")
+	cErr.PutFields(map[string]interface{}{
+		errors.ActualField:   actual,
+		errors.ExpectedField: expect,
+	})
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(expect),
+		B:        difflib.SplitLines(actual),
+		FromFile: "Expected",
+		ToFile:   "Actual",
+		Context:  3,
+	})
+	if err == nil && diff != "" {
+		cErr.PutAttachment(&errors.Attachment{
+			Name:     "Text diff",
+			MimeType: "text/plain",
+			Content:  []byte(diff),
+		})
+	}
+
+	return cErr
+}
+
+// notEqual is a function to assert that body is not equal to the given text
+func notEqual(data []byte, expect string) error {
+	if string(data) == expect {
+		return errors.NewAssertError("NotEqual", fmt.Sprintf("expect text not equal to %q", expect), string(data), expect)
+	}
+
+	return nil
+}
+
+// contains is a function to assert that body contains the given substring
+func contains(data []byte, substr string) error {
+	if !bytes.Contains(data, []byte(substr)) {
+		return errors.NewAssertError("Contains", fmt.Sprintf("expect text to contain %q", substr), string(data), substr)
+	}
+
+	return nil
+}
+
+// notContains is a function to assert that body does not contain the given substring
+func notContains(data []byte, substr string) error {
+	if bytes.Contains(data, []byte(substr)) {
+		return errors.NewAssertError("NotContains", fmt.Sprintf("expect text not to contain %q", substr), string(data), substr)
+	}
+
+	return nil
+}
+
+// hasPrefix is a function to assert that body begins with the given prefix
+func hasPrefix(data []byte, prefix string) error {
+	if !bytes.HasPrefix(data, []byte(prefix)) {
+		return errors.NewAssertError("HasPrefix", fmt.Sprintf("expect text to begin with %q", prefix), string(data), prefix)
+	}
+
+	return nil
+}
+
+// hasSuffix is a function to assert that body ends with the given suffix
+func hasSuffix(data []byte, suffix string) error {
+	if !bytes.HasSuffix(data, []byte(suffix)) {
+		return errors.NewAssertError("HasSuffix", fmt.Sprintf("expect text to end with %q", suffix), string(data), suffix)
+	}
+
+	return nil
+}
+
+// matches is a function to assert that body matches the given regular expression
+func matches(data []byte, pattern string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("could not compile pattern in Matches error: '%s'", err)
+	}
+
+	if !re.Match(data) {
+		return errors.NewAssertError("Matches", fmt.Sprintf("expect text to match %v", pattern), string(data), pattern)
+	}
+
+	return nil
+}
+
+// notMatches is a function to assert that body does not match the given regular expression
+func notMatches(data []byte, pattern string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("could not compile pattern in NotMatches error: '%s'", err)
+	}
+
+	if found := re.Find(data); found != nil {
+		return errors.NewAssertError("NotMatches", fmt.Sprintf("expect text not to match %v", pattern), string(found), pattern)
+	}
+
+	return nil
+}
+
+// lineCount is a function to assert that body has the expected count of lines
+func lineCount(data []byte, expectCount int) error {
+	if count := countLines(data); count != expectCount {
+		return errors.NewAssertError("LineCount", fmt.Sprintf("expect %v lines, but actual %v", expectCount, count), count, expectCount)
+	}
+
+	return nil
+}
+
+func countLines(data []byte) int {
+	if len(data) == 0 {
+		return 0
+	}
+
+	count := bytes.Count(data, []byte("\n"))
+
+	if data[len(data)-1] != '\n' {
+		count++
+	}
+
+	return count
+}
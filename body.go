@@ -0,0 +1,172 @@
+package cute
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ozontech/cute/internal/utils"
+)
+
+// requestBody is a request body, which is read once and replayed for every attempt.
+// Streamed body (multipart form with files from disk) is reopened instead of buffering.
+type requestBody struct {
+	buffered *utils.BufferedBody
+	reopen   func() (io.ReadCloser, error)
+}
+
+// newRequestBody reads request body once
+// After call request body is replaced with buffered one, so request can be read again.
+func newRequestBody(req *http.Request) (*requestBody, error) {
+	if stream, ok := req.Body.(*utils.StreamBody); ok {
+		return &requestBody{reopen: stream.Reopen}, nil
+	}
+
+	buffered, ok := req.Body.(*utils.BufferedBody)
+	if !ok {
+		var err error
+
+		buffered, err = utils.NewBufferedBody(req.Body, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		if req.Body != nil {
+			req.Body = buffered
+		}
+	}
+
+	return &requestBody{buffered: buffered}, nil
+}
+
+// open returns new unread body
+func (b *requestBody) open() (io.ReadCloser, error) {
+	if b.reopen != nil {
+		return b.reopen()
+	}
+
+	if len(b.buffered.Bytes()) == 0 {
+		return http.NoBody, nil
+	}
+
+	return b.buffered.NewReader(), nil
+}
+
+// newRequest returns copy of request with unread body
+func (b *requestBody) newRequest(req *http.Request) (*http.Request, error) {
+	var (
+		err   error
+		clone = req.Clone(req.Context())
+	)
+
+	clone.Body, err = b.open()
+	if err != nil {
+		return nil, err
+	}
+
+	clone.GetBody = b.open
+
+	if b.buffered != nil {
+		clone.ContentLength = int64(len(b.buffered.Bytes()))
+	}
+
+	return clone, nil
+}
+
+// responseBody is a response body, which is read once and shared between allure and asserts
+type responseBody struct {
+	*utils.BufferedBody
+
+	plain     []byte
+	decodeErr error
+	// decodeTruncated is true, if decoded body is larger than max body size
+	decodeTruncated bool
+}
+
+// Truncated returns true, if body or decoded body is larger than max body size
+func (b *responseBody) Truncated() bool {
+	return b.BufferedBody.Truncated() || b.decodeTruncated
+}
+
+// newReader returns body with own read position, so every consumer reads body from the beginning
+// Truncated body has only buffered part, consumer could check it by Truncated.
+func (b *responseBody) newReader() *responseBody {
+	return &responseBody{
+		BufferedBody:    b.BufferedBody.NewReader(),
+		plain:           b.plain,
+		decodeErr:       b.decodeErr,
+		decodeTruncated: b.decodeTruncated,
+	}
+}
+
+// readResponseBody reads response body once and replaces it with buffered one
+// If body is larger than max body size, only part of body is buffered and the rest of body is discarded.
+func (it *Test) readResponseBody(resp *http.Response) (*responseBody, error) {
+	if body, ok := resp.Body.(*responseBody); ok {
+		return body, nil
+	}
+
+	buffered, err := utils.NewBufferedBody(resp.Body, it.maxBodySize)
+	if err != nil {
+		return nil, err
+	}
+
+	body := &responseBody{
+		BufferedBody: buffered,
+		plain:        buffered.Bytes(),
+	}
+
+	// truncated compressed body can't be decoded
+	if !buffered.Truncated() {
+		body.plain, body.decodeTruncated, body.decodeErr = decodeBody(resp.Header, buffered.Bytes(), it.maxBodySize)
+	}
+
+	resp.Body = body
+
+	return body, nil
+}
+
+// readRequestBody returns request body for report without consuming it
+// Second value is false, if body is streamed and could not be shown
+func readRequestBody(req *http.Request) ([]byte, bool, error) {
+	switch body := req.Body.(type) {
+	case nil:
+		return nil, true, nil
+	case *utils.BufferedBody:
+		return body.Bytes(), true, nil
+	case *utils.StreamBody:
+		return nil, false, nil
+	}
+
+	buffered, err := utils.NewBufferedBody(req.Body, 0)
+	if err != nil {
+		return nil, true, err
+	}
+
+	req.Body = buffered
+
+	return buffered.Bytes(), true, nil
+}
+
+// truncateForReport cuts data to max attachment size and adds note about it
+func (it *Test) truncateForReport(data []byte) ([]byte, bool) {
+	if it.maxAttachmentSize <= 0 || len(data) <= it.maxAttachmentSize {
+		return data, false
+	}
+
+	note := fmt.Sprintf("\n\n... truncated, shown %v of %v bytes", it.maxAttachmentSize, len(data))
+
+	res := make([]byte, 0, it.maxAttachmentSize+len(note))
+	res = append(res, data[:it.maxAttachmentSize]...)
+	res = append(res, note...)
+
+	return res, true
+}
+
+func (it *Test) hasBodyExpectations() bool {
+	return len(it.Expect.AssertBody) != 0 ||
+		len(it.Expect.AssertBodyT) != 0 ||
+		it.Expect.JSONSchema.String != "" ||
+		it.Expect.JSONSchema.Byte != nil ||
+		it.Expect.JSONSchema.File != ""
+}
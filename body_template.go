@@ -0,0 +1,225 @@
+package cute
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"os"
+	"path"
+	"path/filepath"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const templateLetters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// defaultTemplateFuncs are functions, which are available in body templates
+//
+//	uuid                   - random UUID v4
+//	now                    - current time in RFC 3339, layout could be passed: {{ now "2006-01-02" }}
+//	unix                   - current unix time in seconds
+//	randomString n         - random string of n letters and digits
+//	randomInt min max      - random integer in [min, max)
+//	env "NAME"             - value of environment variable
+//	base64 "value"         - value in standard base64 encoding
+//	json value             - value marshaled to JSON, for example quoted and escaped string
+//
+// Functions could be added or replaced by WithTemplateFuncs option. Map is never changed, tests get own copy.
+var defaultTemplateFuncs = template.FuncMap{
+	"uuid": func() string {
+		return uuid.NewString()
+	},
+	"now": func(layout ...string) string {
+		if len(layout) != 0 {
+			return time.Now().Format(layout[0])
+		}
+
+		return time.Now().Format(time.RFC3339)
+	},
+	"unix": func() int64 {
+		return time.Now().Unix()
+	},
+	"randomString": randomString,
+	"randomInt":    randomInt,
+	"env":          os.Getenv,
+	"base64": func(s string) string {
+		return base64.StdEncoding.EncodeToString([]byte(s))
+	},
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+
+		return string(data), err
+	},
+}
+
+// WithBodyTemplate is a function for set body in request from text/template file in fsys, for example embed.FS
+// Template is rendered with data and template functions on every request creation,
+// rendered body is sent and shown in Allure body parameter.
+// Example:
+//
+//	//go:embed testdata
+//	var testdata embed.FS
+//
+//	cute.WithBodyTemplate(testdata, "testdata/user.json.tmpl", map[string]interface{}{"Name": "cute"})
+func WithBodyTemplate(fsys fs.FS, name string, data interface{}) RequestBuilder {
+	return func(o *requestOptions) {
+		body, err := renderTemplateFS(fsys, name, data, o.templateFuncs)
+		if err != nil {
+			o.err = err
+
+			return
+		}
+
+		o.body = body
+	}
+}
+
+// WithBodyFile is a function for set body in request from text/template file on disk
+// Template is rendered with data and template functions, see WithBodyTemplate.
+func WithBodyFile(name string, data interface{}) RequestBuilder {
+	return func(o *requestOptions) {
+		body, err := renderTemplateFile(name, data, o.templateFuncs)
+		if err != nil {
+			o.err = err
+
+			return
+		}
+
+		o.body = body
+	}
+}
+
+// WithFileFormTemplate is a function for set file form in request from text/template file in fsys
+// Template is rendered with data and template functions, name of file is a base name of template.
+func WithFileFormTemplate(field string, fsys fs.FS, name string, data interface{}) RequestBuilder {
+	return func(o *requestOptions) {
+		body, err := renderTemplateFS(fsys, name, data, o.templateFuncs)
+		if err != nil {
+			o.err = err
+
+			return
+		}
+
+		o.fileForms[field] = &File{
+			Name: path.Base(name),
+			Body: body,
+		}
+	}
+}
+
+// WithFileFormTemplateFile is a function for set file form in request from text/template file on disk
+// Template is rendered with data and template functions, name of file is a base name of template.
+func WithFileFormTemplateFile(field string, name string, data interface{}) RequestBuilder {
+	return func(o *requestOptions) {
+		body, err := renderTemplateFile(name, data, o.templateFuncs)
+		if err != nil {
+			o.err = err
+
+			return
+		}
+
+		o.fileForms[field] = &File{
+			Name: filepath.Base(name),
+			Body: body,
+		}
+	}
+}
+
+// newTemplateFuncs returns copy of default template functions with added or replaced funcs
+// If funcs are empty, nil is returned and default functions are used as is.
+func newTemplateFuncs(funcs template.FuncMap) template.FuncMap {
+	if len(funcs) == 0 {
+		return nil
+	}
+
+	res := make(template.FuncMap, len(defaultTemplateFuncs)+len(funcs))
+
+	for name, f := range defaultTemplateFuncs {
+		res[name] = f
+	}
+
+	for name, f := range funcs {
+		res[name] = f
+	}
+
+	return res
+}
+
+func renderTemplateFS(fsys fs.FS, name string, data interface{}, funcs template.FuncMap) ([]byte, error) {
+	content, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("could not read template %v error: '%s'", name, err)
+	}
+
+	return renderTemplate(name, content, data, funcs)
+}
+
+func renderTemplateFile(name string, data interface{}, funcs template.FuncMap) ([]byte, error) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("could not read template %v error: '%s'", name, err)
+	}
+
+	return renderTemplate(name, content, data, funcs)
+}
+
+func renderTemplate(name string, content []byte, data interface{}, funcs template.FuncMap) ([]byte, error) {
+	// Test has no own functions, if they are not added by WithTemplateFuncs
+	if funcs == nil {
+		funcs = defaultTemplateFuncs
+	}
+
+	tmpl, err := template.New(path.Base(name)).
+		Option("missingkey=error").
+		Funcs(funcs).
+		Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse template %v error: '%s'", name, err)
+	}
+
+	var buf bytes.Buffer
+
+	if err = tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("could not render template %v error: '%s'", name, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func randomString(n int) (string, error) {
+	if n < 0 {
+		return "", fmt.Errorf("length %v should not be negative", n)
+	}
+
+	res := make([]byte, n)
+
+	for i := range res {
+		idx, err := rand.Int(rand.Reader, big.NewInt(int64(len(templateLetters))))
+		if err != nil {
+			return "", err
+		}
+
+		res[i] = templateLetters[idx.Int64()]
+	}
+
+	return string(res), nil
+}
+
+func randomInt(from, to int) (int, error) {
+	if to <= from {
+		return 0, fmt.Errorf("max %v should be greater than min %v", to, from)
+	}
+
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(to-from)))
+	if err != nil {
+		return 0, err
+	}
+
+	return from + int(n.Int64()), nil
+}
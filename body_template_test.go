@@ -0,0 +1,158 @@
+package cute
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"text/template"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithBodyTemplate(t *testing.T) {
+	t.Setenv("CUTE_TEMPLATE_ENV", "from env")
+
+	fsys := fstest.MapFS{
+		"testdata/user.json.tmpl": &fstest.MapFile{
+			Data: []byte(`{"id":"{{ uuid }}","name":{{ json .Name }},"login":"{{ randomString 8 }}",` +
+				`"age":{{ randomInt 18 19 }},"env":"{{ env "CUTE_TEMPLATE_ENV" }}","token":"{{ base64 "cute" }}","date":"{{ now "2006" }}"}`),
+		},
+	}
+
+	ht := &Test{
+		Request: &Request{
+			Builders: []RequestBuilder{
+				WithURI("http://go.com"),
+				WithBodyTemplate(fsys, "testdata/user.json.tmpl", map[string]interface{}{"Name": `"cute"`}),
+			},
+		},
+	}
+
+	req, err := ht.createRequest(context.Background())
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+
+	var user map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &user))
+
+	_, err = uuid.Parse(user["id"].(string))
+	require.NoError(t, err)
+	require.Equal(t, `"cute"`, user["name"])
+	require.Len(t, user["login"], 8)
+	require.Equal(t, float64(18), user["age"])
+	require.Equal(t, "from env", user["env"])
+	require.Equal(t, "Y3V0ZQ==", user["token"])
+	require.Len(t, user["date"], 4)
+}
+
+func TestWithBodyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "body.tmpl")
+	require.NoError(t, os.WriteFile(path, []byte(`{"name":"{{ .Name }}"}`), 0600))
+
+	ht := &Test{
+		Request: &Request{
+			Builders: []RequestBuilder{
+				WithURI("http://go.com"),
+				WithBodyFile(path, struct{ Name string }{Name: "cute"}),
+			},
+		},
+	}
+
+	req, err := ht.createRequest(context.Background())
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"cute"}`, string(body))
+}
+
+func TestWithFileFormTemplate(t *testing.T) {
+	fsys := fstest.MapFS{
+		"data/users.csv.tmpl": &fstest.MapFile{Data: []byte("name\n{{ range . }}{{ . }}\n{{ end }}")},
+	}
+
+	ht := &Test{
+		Request: &Request{
+			Builders: []RequestBuilder{
+				WithURI("http://go.com"),
+				WithMethod("POST"),
+				WithFileFormTemplate("file", fsys, "data/users.csv.tmpl", []string{"a", "b"}),
+			},
+		},
+	}
+
+	req, err := ht.createRequest(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, req.ParseMultipartForm(1024))
+
+	f, header, err := req.FormFile("file")
+	require.NoError(t, err)
+	require.Equal(t, "users.csv.tmpl", header.Filename)
+
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	require.Equal(t, "name\na\nb\n", string(content))
+}
+
+func TestWithBodyTemplateError(t *testing.T) {
+	fsys := fstest.MapFS{
+		"broken.tmpl":  &fstest.MapFile{Data: []byte(`{{ .Name `)},
+		"missing.tmpl": &fstest.MapFile{Data: []byte(`{{ .Name }}`)},
+	}
+
+	for name, builder := range map[string]RequestBuilder{
+		"could not read template":   WithBodyTemplate(fsys, "not_found.tmpl", nil),
+		"could not parse template":  WithBodyTemplate(fsys, "broken.tmpl", nil),
+		"could not render template": WithBodyTemplate(fsys, "missing.tmpl", map[string]string{}),
+	} {
+		ht := &Test{
+			Request: &Request{
+				Builders: []RequestBuilder{WithURI("http://go.com"), builder},
+			},
+		}
+
+		_, err := ht.createRequest(context.Background())
+		require.ErrorContains(t, err, name)
+	}
+}
+
+func TestWithTemplateFuncs(t *testing.T) {
+	fsys := fstest.MapFS{
+		"name.tmpl": &fstest.MapFile{Data: []byte(`{{ upper .Name }}-{{ uuid }}`)},
+	}
+
+	m := NewHTTPTestMaker(WithTemplateFuncs(template.FuncMap{
+		"upper": strings.ToUpper,
+		"uuid":  func() string { return "fixed" },
+	}))
+
+	ht := createDefaultTest(m)
+	ht.Request.Builders = []RequestBuilder{
+		WithURI("http://go.com"),
+		WithBodyTemplate(fsys, "name.tmpl", map[string]string{"Name": "cute"}),
+	}
+
+	req, err := ht.createRequest(context.Background())
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	require.Equal(t, "CUTE-fixed", string(body))
+
+	// Default functions are not changed
+	require.NotContains(t, defaultTemplateFuncs, "upper")
+	require.Nil(t, createDefaultTest(NewHTTPTestMaker()).templateFuncs)
+}
+
+func TestRandomStringNegative(t *testing.T) {
+	_, err := randomString(-1)
+	require.EqualError(t, err, "length -1 should not be negative")
+}
@@ -0,0 +1,231 @@
+package cute
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/cute/internal/utils"
+)
+
+func TestStreamFileFormWithRetry(t *testing.T) {
+	var (
+		attempts int32
+		content  = strings.Repeat("cute", 1024)
+		path     = filepath.Join(t.TempDir(), "upload.txt")
+	)
+
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("file")
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(file)
+		require.NoError(t, err)
+		require.Equal(t, content, string(data))
+		require.Equal(t, "value", r.FormValue("field"))
+
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	test := &Test{
+		httpClient: ts.Client(),
+		Request: &Request{
+			Builders: []RequestBuilder{
+				WithMethod(http.MethodPost),
+				WithURI(ts.URL),
+				WithFileFormKV("file", &File{Path: path}),
+				WithFormKV("field", []byte("value")),
+			},
+			Retry: &RequestRetryPolitic{
+				Count: 2,
+				Delay: 1,
+			},
+		},
+		Expect: &Expect{Code: http.StatusOK},
+	}
+
+	test.initEmptyFields()
+
+	req, err := test.createRequest(context.Background())
+	require.NoError(t, err)
+
+	// file is streamed again for the second attempt
+	resp, _ := test.makeRequest(createAllureT(t), req)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func TestCreateRequestFileFormNotFound(t *testing.T) {
+	test := &Test{
+		Request: &Request{
+			Builders: []RequestBuilder{
+				WithMethod(http.MethodPost),
+				WithURI("http://go.com"),
+				WithFileFormKV("file", &File{Path: filepath.Join(t.TempDir(), "not_found")}),
+			},
+		},
+	}
+
+	_, err := test.createRequest(context.Background())
+	require.Error(t, err)
+}
+
+func TestCreateRequestFileFormPathName(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "file.txt")
+	require.NoError(t, os.WriteFile(path, []byte("content"), 0600))
+
+	test := &Test{
+		Request: &Request{
+			Builders: []RequestBuilder{
+				WithMethod(http.MethodPost),
+				WithURI("http://go.com"),
+				WithFileFormKV("file", &File{Path: path, Name: "avatar.png"}),
+			},
+		},
+	}
+
+	req, err := test.createRequest(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, req.ParseMultipartForm(1024))
+
+	// file with Path is sent with its path as name
+	_, header, err := req.FormFile("file")
+	require.NoError(t, err)
+	require.Equal(t, "file.txt", header.Filename)
+}
+
+func TestValidateResponseMaxBodySize(t *testing.T) {
+	var (
+		body = strings.Repeat("a", 100)
+		ht   = &Test{
+			maxBodySize: 10,
+			Expect: &Expect{
+				JSONSchema: new(ExpectJSONSchema),
+				AssertBody: []AssertBody{
+					func(body []byte) error {
+						return errors.New("body assert must not be executed")
+					},
+				},
+				AssertResponse: []AssertResponse{
+					func(resp *http.Response) error {
+						data, err := io.ReadAll(resp.Body)
+						if err != nil {
+							return err
+						}
+
+						if len(data) != 10 {
+							return errors.New("only buffered part of body must be available")
+						}
+
+						return nil
+					},
+				},
+			},
+		}
+		source = &closeRecorder{Reader: strings.NewReader(body)}
+		resp   = &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       source,
+		}
+	)
+
+	ht.initEmptyFields()
+
+	errs := ht.validateResponse(createAllureT(t), resp)
+	require.Len(t, errs, 1)
+	require.Contains(t, errs[0].Error(), "larger than 10 bytes")
+
+	// Source body is closed after buffered part is read, so connection isn't kept
+	require.True(t, source.closed)
+}
+
+// closeRecorder is a body, which records Close
+type closeRecorder struct {
+	io.Reader
+
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+
+	return nil
+}
+
+func TestReadResponseBodyMaxDecodedSize(t *testing.T) {
+	for _, encoding := range []string{EncodingGzip, EncodingDeflate, EncodingZstd} {
+		// 8 MB of zeros is compressed to several kilobytes
+		encoded, err := utils.EncodeBody(encoding, make([]byte, 8<<20))
+		require.NoError(t, err)
+		require.Less(t, len(encoded), 64<<10)
+
+		var (
+			ht   = &Test{maxBodySize: 64 << 10}
+			resp = &http.Response{
+				Header: http.Header{"Content-Encoding": []string{encoding}},
+				Body:   io.NopCloser(bytes.NewReader(encoded)),
+			}
+		)
+
+		body, err := ht.readResponseBody(resp)
+		require.NoError(t, err)
+		require.NoError(t, body.decodeErr)
+		require.True(t, body.Truncated(), encoding)
+		require.Len(t, body.plain, 64<<10, encoding)
+	}
+}
+
+func TestReadResponseBodyOnce(t *testing.T) {
+	var (
+		ht   = &Test{}
+		resp = &http.Response{
+			Body: io.NopCloser(strings.NewReader("body")),
+		}
+	)
+
+	first, err := ht.readResponseBody(resp)
+	require.NoError(t, err)
+
+	second, err := ht.readResponseBody(resp)
+	require.NoError(t, err)
+
+	require.Same(t, first, second)
+	require.Equal(t, "body", string(second.plain))
+}
+
+func TestTruncateForReport(t *testing.T) {
+	ht := &Test{maxAttachmentSize: 4}
+
+	res, truncated := ht.truncateForReport([]byte("1234567890"))
+	require.True(t, truncated)
+	require.True(t, strings.HasPrefix(string(res), "1234"))
+	require.Contains(t, string(res), "shown 4 of 10 bytes")
+
+	res, truncated = ht.truncateForReport([]byte("123"))
+	require.False(t, truncated)
+	require.Equal(t, "123", string(res))
+
+	ht.maxAttachmentSize = 0
+
+	_, truncated = ht.truncateForReport([]byte("1234567890"))
+	require.False(t, truncated)
+}
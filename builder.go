@@ -1,11 +1,18 @@
 package cute
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"net/url"
+	"text/template"
 	"time"
 )
 
-const defaultHTTPTimeout = 30
+const (
+	defaultHTTPTimeout       = 30
+	defaultMaxAttachmentSize = 10 * 1024 * 1024
+)
 
 var (
 	errorAssertIsNil = "assert must be not nil"
@@ -16,6 +23,30 @@ type HTTPTestMaker struct {
 	httpClient    *http.Client
 	middleware    *Middleware
 	jsonMarshaler JSONMarshaler
+
+	maxBodySize       int64
+	maxAttachmentSize int
+
+	redaction *redactionRules
+
+	jsonSchemaRegistry *JSONSchemaRegistry
+
+	profile *Profile
+	baseURL *url.URL
+
+	selection selection
+
+	suite *suite
+
+	coverage *Coverage
+
+	limiter *limiter
+
+	templateFuncs template.FuncMap
+
+	// err is an error of options, for example transport could not be built from files of config
+	// Tests of maker are broken with this error.
+	err error
 }
 
 // NewHTTPTestMaker is function for set options for all cute.
@@ -24,7 +55,23 @@ type HTTPTestMaker struct {
 // - WithCustomHTTPTimeout - set timeout for all requests
 // - WithHTTPClient - set custom http client
 // - WithCustomHTTPRoundTripper - set custom http round tripper
+// - WithTransport - set mTLS, CA files, proxy, protocol or unix socket of http transport
 // - WithJSONMarshaler - set custom json marshaler
+// - WithMaxBodySize - set max size of response body for asserts
+// - WithTemplateFuncs - add or replace functions of body templates
+// - WithMaxAttachmentSize - set max size of bodies in allure report
+// - WithRedactionPolicy - set policy for hide sensitive data in logs and allure report
+// - WithJSONSchemaRegistry - set registry for resolve and cache JSON schemas
+// - WithBaseURL - set base url for relative URIs of requests
+// - WithProfile - set environment profile with base url, default headers and timeout
+// - WithTagFilter - select tests by boolean expression of tags
+// - WithMinSeverity - select tests by minimal severity
+// - WithLayers - select tests by layers
+// - WithBeforeAll - set function which will run once BEFORE first test
+// - WithAfterAll - set function which will run once AFTER all tests by Close
+// - WithCoverage - record executed operations of OpenAPI document
+// - WithRateLimit - set rate limit of requests per host
+// - WithMaxInFlightPerHost - set max count of in-flight requests per host
 // - WithMiddlewareAfter - set function which will run AFTER test execution
 // - WithMiddlewareAfterT - set function which will run AFTER test execution with TB
 // - WithMiddlewareBefore - set function which will run BEFORE test execution
@@ -44,10 +91,31 @@ func NewHTTPTestMaker(opts ...Option) *HTTPTestMaker {
 		opt(o)
 	}
 
+	if o.profile != nil && o.profile.Timeout != 0 {
+		timeout = o.profile.Timeout
+	}
+
 	if o.httpTimeout != 0 {
 		timeout = o.httpTimeout
 	}
 
+	transport := o.transport
+	if transport == nil && o.profile != nil {
+		transport = o.profile.Transport
+	}
+
+	// Files of transport config are user input, so error is reported by tests instead of panic
+	var transportErr error
+
+	if transport != nil {
+		rt, err := transport.RoundTripper()
+		if err != nil {
+			transportErr = fmt.Errorf("could not build transport error: '%s'", err)
+		} else {
+			roundTripper = rt
+		}
+	}
+
 	if o.httpRoundTripper != nil { //nolint
 		roundTripper = o.httpRoundTripper
 	}
@@ -65,15 +133,80 @@ func NewHTTPTestMaker(opts ...Option) *HTTPTestMaker {
 		jsMarshaler = o.jsonMarshaler
 	}
 
+	maxAttachmentSize := defaultMaxAttachmentSize
+	if o.maxAttachmentSize != 0 {
+		maxAttachmentSize = o.maxAttachmentSize
+	}
+
+	baseURL := o.baseURL
+	if baseURL == "" && o.profile != nil {
+		baseURL = o.profile.BaseURL
+	}
+
 	m := &HTTPTestMaker{
-		httpClient:    httpClient,
-		jsonMarshaler: jsMarshaler,
-		middleware:    o.middleware,
+		httpClient:         httpClient,
+		jsonMarshaler:      jsMarshaler,
+		middleware:         o.middleware,
+		maxBodySize:        o.maxBodySize,
+		maxAttachmentSize:  maxAttachmentSize,
+		jsonSchemaRegistry: o.jsonSchemaRegistry,
+		profile:            o.profile,
+		selection:          o.selection,
+		coverage:           o.coverage,
+		limiter:            newLimiter(o.rateLimit, o.rateBurst, o.maxInFlight),
+		templateFuncs:      o.templateFuncs,
+		err:                transportErr,
+	}
+
+	// Suite is created only for hooks, so maker without hooks doesn't track tests
+	if len(o.beforeAll) != 0 || len(o.afterAll) != 0 {
+		m.suite = newSuite(o.beforeAll, o.afterAll)
+	}
+
+	if baseURL != "" {
+		u, err := url.Parse(baseURL)
+		if err != nil || !u.IsAbs() {
+			panic(fmt.Sprintf("could not parse base url %v, url should be absolute", baseURL))
+		}
+
+		m.baseURL = u
+	}
+
+	redaction, err := compileRedactionPolicy(o.redactionPolicy)
+	if err != nil {
+		panic(fmt.Sprintf("could not compile redaction policy error: '%s'", err))
 	}
 
+	m.redaction = redaction
+
 	return m
 }
 
+// Close is a function for run AfterAll functions, it waits tests of HTTPTestMaker, which are executed now.
+// AfterAll functions are run once and only if any test was executed.
+// Befores and afters are written to allure report as container of executed tests.
+// Tests could not be executed after Close, they are broken.
+// If Close is not called, AfterAll functions are not run and container is not written to allure report,
+// so Close should be called once after all tests, for example in TestMain.
+// Example:
+//
+//	func TestMain(m *testing.M) {
+//		code := m.Run()
+//
+//		if err := maker.Close(); err != nil {
+//			log.Println(err)
+//		}
+//
+//		os.Exit(code)
+//	}
+func (m *HTTPTestMaker) Close() error {
+	if m.suite == nil {
+		return nil
+	}
+
+	return m.suite.close(context.Background())
+}
+
 // NewTestBuilder is a function for initialization foundation for cute
 func (m *HTTPTestMaker) NewTestBuilder() AllureBuilder {
 	tests := createDefaultTests(m)
@@ -98,10 +231,19 @@ func createDefaultTests(m *HTTPTestMaker) []*Test {
 
 func createDefaultTest(m *HTTPTestMaker) *Test {
 	return &Test{
-		httpClient:    m.httpClient,
-		jsonMarshaler: m.jsonMarshaler,
-		Middleware:    createMiddlewareFromTemplate(m.middleware),
-		AllureStep:    new(AllureStep),
+		httpClient:         m.httpClient,
+		jsonMarshaler:      m.jsonMarshaler,
+		maxBodySize:        m.maxBodySize,
+		maxAttachmentSize:  m.maxAttachmentSize,
+		redactor:           newRedactor(m.redaction),
+		jsonSchemaRegistry: m.jsonSchemaRegistry,
+		baseURL:            m.baseURL,
+		defaultHeaders:     profileHeaders(m.profile),
+		coverage:           m.coverage,
+		limiter:            m.limiter,
+		templateFuncs:      newTemplateFuncs(m.templateFuncs),
+		Middleware:         createMiddlewareFromTemplate(m.middleware),
+		AllureStep:         new(AllureStep),
 		Request: &Request{
 			Retry: new(RequestRetryPolitic),
 		},
@@ -109,6 +251,14 @@ func createDefaultTest(m *HTTPTestMaker) *Test {
 	}
 }
 
+func profileHeaders(profile *Profile) map[string]string {
+	if profile == nil {
+		return nil
+	}
+
+	return profile.Headers
+}
+
 func createMiddlewareFromTemplate(m *Middleware) *Middleware {
 	after := make([]AfterExecute, 0, len(m.After))
 	after = append(after, m.After...)
@@ -128,6 +128,34 @@ func (qt *cute) AssertResponse(asserts ...AssertResponse) ExpectHTTPBuilder {
 	return qt
 }
 
+func (qt *cute) AssertRace(asserts ...AssertRace) ExpectHTTPBuilder {
+	trace := getTrace()
+
+	for _, assert := range asserts {
+		if assert == nil {
+			panic(errorAssertIsNil)
+		}
+
+		qt.tests[qt.countTests].Expect.AssertRace = append(qt.tests[qt.countTests].Expect.AssertRace, assertRaceWithTrace(assert, trace))
+	}
+
+	return qt
+}
+
+func (qt *cute) AssertItems(asserts ...AssertItems) ExpectHTTPBuilder {
+	trace := getTrace()
+
+	for _, assert := range asserts {
+		if assert == nil {
+			panic(errorAssertIsNil)
+		}
+
+		qt.tests[qt.countTests].Expect.AssertItems = append(qt.tests[qt.countTests].Expect.AssertItems, assertItemsWithTrace(assert, trace))
+	}
+
+	return qt
+}
+
 func (qt *cute) OptionalAssertResponse(asserts ...AssertResponse) ExpectHTTPBuilder {
 	trace := getTrace()
 
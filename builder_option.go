@@ -2,17 +2,45 @@ package cute
 
 import (
 	"net/http"
+	"strings"
+	"text/template"
 	"time"
+
+	"github.com/ozontech/allure-go/pkg/allure"
 )
 
 type options struct {
 	httpClient       *http.Client
 	httpTimeout      time.Duration
 	httpRoundTripper http.RoundTripper
+	transport        *TransportConfig
 
 	jsonMarshaler JSONMarshaler
 
 	middleware *Middleware
+
+	maxBodySize       int64
+	maxAttachmentSize int
+
+	redactionPolicy *RedactionPolicy
+
+	jsonSchemaRegistry *JSONSchemaRegistry
+
+	baseURL string
+	profile *Profile
+
+	selection selection
+
+	beforeAll []BeforeAll
+	afterAll  []AfterAll
+
+	coverage *Coverage
+
+	rateLimit   float64
+	rateBurst   int
+	maxInFlight int
+
+	templateFuncs template.FuncMap
 }
 
 // Option ...
@@ -25,6 +53,16 @@ func WithHTTPClient(client *http.Client) Option {
 	}
 }
 
+// WithTransport is a function for set configuration of http transport: client certificate, CA files,
+// proxy, protocol and unix socket. Timeout of http client is kept.
+// Transport overrides transport of profile, WithCustomHTTPRoundTripper and WithHTTPClient override transport.
+// If certificates could not be loaded, tests of maker are broken with error of transport.
+func WithTransport(config TransportConfig) Option {
+	return func(o *options) {
+		o.transport = &config
+	}
+}
+
 // WithJSONMarshaler is a function for set custom json marshaler
 func WithJSONMarshaler(m JSONMarshaler) Option {
 	return func(o *options) {
@@ -46,6 +84,131 @@ func WithCustomHTTPRoundTripper(r http.RoundTripper) Option {
 	}
 }
 
+// WithMaxBodySize is a function for set max size of response body in bytes, which is read for asserts.
+// If response body is larger, body asserts and JSON schema validation are failed without execution,
+// rest of body is not read, so AssertResponse gets only first size bytes of body.
+// By default, size is not limited.
+func WithMaxBodySize(size int64) Option {
+	return func(o *options) {
+		o.maxBodySize = size
+	}
+}
+
+// WithMaxAttachmentSize is a function for set max size of request and response bodies in bytes,
+// which are attached to allure report. Larger bodies are truncated with note.
+// Default size is 10 MB. Use negative size to disable limit.
+func WithMaxAttachmentSize(size int) Option {
+	return func(o *options) {
+		o.maxAttachmentSize = size
+	}
+}
+
+// WithJSONSchemaRegistry is a function for set registry of JSON schemas.
+// Registry resolves $ref from local directory or embed.FS and caches compiled schemas.
+// ExpectJSONSchemaFile with path without scheme is resolved from root of registry.
+func WithJSONSchemaRegistry(registry *JSONSchemaRegistry) Option {
+	return func(o *options) {
+		o.jsonSchemaRegistry = registry
+	}
+}
+
+// WithBaseURL is a function for set base url of requests.
+// Relative URIs, like WithURI("/users"), are resolved from base url, path of base url is kept.
+// Base url overrides BaseURL of profile.
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithProfile is a function for set environment profile, for example loaded by LoadProfile.
+// Profile sets base url, default headers and timeout of requests,
+// name of profile is added to allure report as environment label and parameter.
+func WithProfile(profile *Profile) Option {
+	if profile == nil {
+		panic("profile is nil in WithProfile")
+	}
+
+	return func(o *options) {
+		o.profile = profile
+	}
+}
+
+// WithTagFilter is a function for select tests by boolean expression of tags.
+// Operators: ! - not, , or & - and, | - or, parentheses group expressions, for example smoke,!slow.
+// Not matched tests are skipped before execution. CUTE_TAGS environment variable overrides filter.
+func WithTagFilter(expression string) Option {
+	return func(o *options) {
+		o.selection.tags = expression
+	}
+}
+
+// WithMinSeverity is a function for select tests with severity not lower than severity.
+// Test without severity has normal severity. CUTE_MIN_SEVERITY environment variable overrides severity.
+func WithMinSeverity(severity allure.SeverityType) Option {
+	return func(o *options) {
+		o.selection.minSeverity = string(severity)
+	}
+}
+
+// WithLayers is a function for select tests by layer.
+// CUTE_LAYERS environment variable with comma separated layers overrides layers.
+func WithLayers(layers ...string) Option {
+	return func(o *options) {
+		o.selection.layers = strings.Join(layers, ",")
+	}
+}
+
+// WithRedactionPolicy is a function for set policy, which hides sensitive data in logs and allure report.
+// Policy is applied to copies of requests and responses, real requests and asserts are not changed.
+func WithRedactionPolicy(policy *RedactionPolicy) Option {
+	return func(o *options) {
+		o.redactionPolicy = policy
+	}
+}
+
+// WithBeforeAll is function for set function which will run once BEFORE first test of HTTPTestMaker.
+// If function returns error, all tests of HTTPTestMaker are broken with this error.
+func WithBeforeAll(before ...BeforeAll) Option {
+	return func(o *options) {
+		o.beforeAll = append(o.beforeAll, before...)
+	}
+}
+
+// WithAfterAll is function for set function which will run once AFTER all tests of HTTPTestMaker.
+// Functions are run by HTTPTestMaker.Close, they are not run, if Close is not called.
+func WithAfterAll(after ...AfterAll) Option {
+	return func(o *options) {
+		o.afterAll = append(o.afterAll, after...)
+	}
+}
+
+// WithCoverage is a function for record requests of tests to coverage of OpenAPI document.
+// Coverage could be shared between makers, report is written by Coverage.WriteReport.
+func WithCoverage(coverage *Coverage) Option {
+	return func(o *options) {
+		o.coverage = coverage
+	}
+}
+
+// WithRateLimit is a function for set token bucket rate limit of requests per host.
+// Burst is a count of requests, which could be sent at once, it's 1 if burst is not positive.
+// Every retry of request waits for limit too, time of waiting is logged and added to allure step.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(o *options) {
+		o.rateLimit = requestsPerSecond
+		o.rateBurst = burst
+	}
+}
+
+// WithMaxInFlightPerHost is a function for set max count of requests per host, which are executed at the same time.
+// Request is in-flight until body of response is read.
+func WithMaxInFlightPerHost(count int) Option {
+	return func(o *options) {
+		o.maxInFlight = count
+	}
+}
+
 // WithMiddlewareAfter is function for set function which will run AFTER test execution
 func WithMiddlewareAfter(after ...AfterExecute) Option {
 	return func(o *options) {
@@ -73,3 +236,18 @@ func WithMiddlewareBeforeT(beforeT ...BeforeExecuteT) Option {
 		o.middleware.BeforeT = append(o.middleware.BeforeT, beforeT...)
 	}
 }
+
+// WithTemplateFuncs is a function for add or replace functions of body templates, see WithBodyTemplate.
+// Functions are copied to every test, so map could not be changed after maker is created.
+// Example: WithTemplateFuncs(template.FuncMap{"upper": strings.ToUpper})
+func WithTemplateFuncs(funcs template.FuncMap) Option {
+	return func(o *options) {
+		if o.templateFuncs == nil {
+			o.templateFuncs = make(template.FuncMap, len(funcs))
+		}
+
+		for name, f := range funcs {
+			o.templateFuncs[name] = f
+		}
+	}
+}
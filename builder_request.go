@@ -72,6 +72,42 @@ func (qt *cute) RequestRetry(count int) RequestHTTPBuilder {
 	return qt
 }
 
+// RequestRace is a function for send the same request count times at the same time
+func (qt *cute) RequestRace(count int) RequestHTTPBuilder {
+	qt.tests[qt.countTests].Request.Race = count
+
+	return qt
+}
+
+// RequestIdempotency is a function for replay request with the same idempotency key
+func (qt *cute) RequestIdempotency(idempotency Idempotency) RequestHTTPBuilder {
+	qt.tests[qt.countTests].Request.Idempotency = &idempotency
+
+	return qt
+}
+
+// RequestPagination is a function for follow pages of list request until they are exhausted
+func (qt *cute) RequestPagination(pagination Pagination) RequestHTTPBuilder {
+	if pagination.Next == nil {
+		panic("next page is nil in RequestPagination")
+	}
+
+	qt.tests[qt.countTests].Request.Pagination = &pagination
+
+	return qt
+}
+
+// PollUntil is a function for send request repeatedly until response is terminal
+func (qt *cute) PollUntil(poll Poll) RequestHTTPBuilder {
+	if poll.Until == nil {
+		panic("condition is nil in PollUntil")
+	}
+
+	qt.tests[qt.countTests].Request.Poll = &poll
+
+	return qt
+}
+
 // RequestRetryDelay set delay for request repeat.
 // if response.Code != Expect.Code, than request will repeat Count counts with Delay delay.
 // Default delay is 1 second.
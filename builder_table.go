@@ -66,6 +66,16 @@ func (qt *cute) fillBaseProps(t *Test) {
 		t.jsonMarshaler = qt.baseProps.jsonMarshaler
 	}
 
+	t.maxBodySize = qt.baseProps.maxBodySize
+	t.maxAttachmentSize = qt.baseProps.maxAttachmentSize
+	t.redactor = newRedactor(qt.baseProps.redaction)
+	t.jsonSchemaRegistry = qt.baseProps.jsonSchemaRegistry
+	t.baseURL = qt.baseProps.baseURL
+	t.defaultHeaders = profileHeaders(qt.baseProps.profile)
+	t.limiter = qt.baseProps.limiter
+	t.coverage = qt.baseProps.coverage
+	t.templateFuncs = newTemplateFuncs(qt.baseProps.templateFuncs)
+
 	if t.Middleware == nil {
 		t.Middleware = createMiddlewareFromTemplate(qt.baseProps.middleware)
 	} else {
@@ -83,3 +93,12 @@ func (qt *cute) NextTest() NextTestBuilder {
 
 	return qt
 }
+
+func (qt *cute) Finally(name string) MiddlewareRequest {
+	qt.NextTest()
+
+	qt.tests[qt.countTests].AllureStep.Name = name
+	qt.tests[qt.countTests].finally = true
+
+	return qt
+}
@@ -0,0 +1,71 @@
+// Command cute-curl prints cute test builder with request from curl command.
+//
+// Usage:
+//
+//	cute-curl curl -X POST 'https://example.com/users' -d '{"name":"cute"}'
+//	pbpaste | cute-curl
+//
+// Command is taken from arguments or from stdin, if there are no arguments.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ozontech/cute/internal/curl"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "cute-curl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	var command string
+
+	if len(args) != 0 {
+		if args[0] != "curl" {
+			args = append([]string{"curl"}, args...)
+		}
+
+		quoted := make([]string, 0, len(args))
+		for _, arg := range args {
+			quoted = append(quoted, shellQuote(arg))
+		}
+
+		command = strings.Join(quoted, " ")
+	} else {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return err
+		}
+
+		command = strings.TrimSpace(string(data))
+		if !strings.HasPrefix(command, "curl ") {
+			command = "curl " + command
+		}
+	}
+
+	cmd, err := curl.Parse(command)
+	if err != nil {
+		return err
+	}
+
+	src, err := printBuilder(cmd)
+	if err != nil {
+		return err
+	}
+
+	_, err = stdout.Write(src)
+
+	return err
+}
+
+// shellQuote quotes argument, which is already split by shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
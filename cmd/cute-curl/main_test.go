@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunArgs(t *testing.T) {
+	var out bytes.Buffer
+
+	err := run([]string{"curl", "-X", "PUT", "https://go.com/users/1?a=1", "-H", "X-Id: 1", "-d", "{\"name\":\"cute\"}"}, nil, &out)
+	require.NoError(t, err)
+
+	require.Equal(t, "cute.NewTestBuilder().\n"+
+		"\tTitle(\"PUT /users/1\").\n"+
+		"\tCreate().\n"+
+		"\tRequestBuilder(\n"+
+		"\t\tcute.WithURI(\"https://go.com/users/1\"),\n"+
+		"\t\tcute.WithMethod(http.MethodPut),\n"+
+		"\t\tcute.WithQueryKV(\"a\", \"1\"),\n"+
+		"\t\tcute.WithHeadersKV(\"Content-Type\", \"application/x-www-form-urlencoded\"),\n"+
+		"\t\tcute.WithHeadersKV(\"X-Id\", \"1\"),\n"+
+		"\t\tcute.WithBody([]byte(`{\"name\":\"cute\"}`)),\n"+
+		"\t).\n"+
+		"\tExecuteTest(context.Background(), t)\n", out.String())
+}
+
+func TestRunStdin(t *testing.T) {
+	var out bytes.Buffer
+
+	stdin := strings.NewReader("curl 'http://go.com/upload' \\\n  -F 'file=@a.png;filename=b.png' \\\n  -H 'X-Id: 1' -H 'X-Id: 2'\n")
+
+	require.NoError(t, run(nil, stdin, &out))
+
+	code := out.String()
+	require.Contains(t, code, "cute.WithMethod(http.MethodPost)")
+	require.Contains(t, code, `cute.WithFileFormKV("file", &cute.File{Path: "a.png"}), // filename=b.png, set Name and Body of file for keep it`)
+	require.Contains(t, code, `"X-Id": {"1", "2"},`)
+}
+
+func TestRunError(t *testing.T) {
+	require.Error(t, run([]string{"--unknown", "http://go.com"}, nil, &bytes.Buffer{}))
+}
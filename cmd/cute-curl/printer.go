@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/ozontech/cute/internal/codegen"
+	"github.com/ozontech/cute/internal/curl"
+)
+
+// printBuilder returns Go code of test builder with request from curl command
+func printBuilder(cmd *curl.Command) ([]byte, error) {
+	u, err := url.Parse(cmd.URL)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse url %v error: '%s'", cmd.URL, err)
+	}
+
+	query := u.Query()
+	u.RawQuery = ""
+
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "cute.NewTestBuilder().\n")
+	fmt.Fprintf(&b, "Title(%v).\n", codegen.GoString(cmd.Method+" "+u.Path))
+	fmt.Fprintf(&b, "Create().\n")
+	fmt.Fprintf(&b, "RequestBuilder(\n")
+	fmt.Fprintf(&b, "cute.WithURI(%v),\n", codegen.GoString(u.String()))
+
+	fmt.Fprintf(&b, "cute.WithMethod(%v),\n", codegen.MethodName(cmd.Method))
+
+	printValues(&b, "Query", query)
+	printValues(&b, "Headers", headerValues(cmd.Headers))
+
+	if cmd.Body != nil {
+		fmt.Fprintf(&b, "cute.WithBody([]byte(%v)),\n", codegen.GoRawString(string(cmd.Body)))
+	}
+
+	for _, form := range cmd.Forms {
+		if form.File != "" {
+			fmt.Fprintf(&b, "cute.WithFileFormKV(%v, &cute.File{Path: %v}),", codegen.GoString(form.Name), codegen.GoString(form.File))
+
+			// File with Path is sent with its path as name, filename of form is kept only with Name and Body
+			if form.FileName != "" {
+				fmt.Fprintf(&b, " // filename=%v, set Name and Body of file for keep it", form.FileName)
+			}
+
+			fmt.Fprintf(&b, "\n")
+
+			continue
+		}
+
+		fmt.Fprintf(&b, "cute.WithFormKV(%v, []byte(%v)),\n", codegen.GoString(form.Name), codegen.GoRawString(form.Value))
+	}
+
+	fmt.Fprintf(&b, ").\n")
+	fmt.Fprintf(&b, "ExecuteTest(context.Background(), t)\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("could not format code error: '%s'", err)
+	}
+
+	return src, nil
+}
+
+// printValues prints KV builder for single values and map builder for several values of the same key
+func printValues(b *bytes.Buffer, name string, values map[string][]string) {
+	if len(values) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(values))
+	multiple := false
+
+	for key, v := range values {
+		keys = append(keys, key)
+
+		if len(v) > 1 {
+			multiple = true
+		}
+	}
+
+	sort.Strings(keys)
+
+	if !multiple {
+		for _, key := range keys {
+			fmt.Fprintf(b, "cute.With%vKV(%v, %v),\n", name, codegen.GoString(key), codegen.GoString(values[key][0]))
+		}
+
+		return
+	}
+
+	fmt.Fprintf(b, "cute.With%v(map[string][]string{\n", name)
+
+	for _, key := range keys {
+		quoted := make([]string, 0, len(values[key]))
+		for _, v := range values[key] {
+			quoted = append(quoted, codegen.GoString(v))
+		}
+
+		fmt.Fprintf(b, "%v: {%v},\n", codegen.GoString(key), strings.Join(quoted, ", "))
+	}
+
+	fmt.Fprintf(b, "}),\n")
+}
+
+func headerValues(headers []curl.Header) map[string][]string {
+	res := make(map[string][]string, len(headers))
+
+	for _, h := range headers {
+		res[h.Name] = append(res[h.Name], h.Value)
+	}
+
+	return res
+}
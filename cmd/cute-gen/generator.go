@@ -0,0 +1,433 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/ozontech/cute/internal/codegen"
+	"github.com/ozontech/cute/internal/openapi"
+)
+
+var testFileTemplate = template.Must(template.New("test").Parse(`// Tests are generated by cute-gen from {{.Spec}}.
+// They are a baseline for new endpoints, add asserts of your API to them.
+
+package {{.Package}}
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ozontech/cute"
+)
+
+const baseURL = {{.BaseURL}}
+{{range .Tests}}
+// {{.Name}} checks {{.Method}} {{.Path}}
+// Documented responses: {{.Responses}}
+{{- range .Todo}}
+// TODO: {{.}}
+{{- end}}
+func {{.Name}}(t *testing.T) {
+	cute.NewTestBuilder().
+		Title({{.Title}}).
+		{{- if .Description}}
+		Description({{.Description}}).
+		{{- end}}
+		{{- if .Tags}}
+		Tags({{.Tags}}).
+		{{- end}}
+		{{- if .Feature}}
+		Feature({{.Feature}}).
+		{{- end}}
+		{{- if .Story}}
+		Story({{.Story}}).
+		{{- end}}
+		Create().
+		RequestBuilder(
+			cute.WithURI(baseURL + {{.URI}}),
+			cute.WithMethod({{.MethodConst}}),
+			{{- range .Query}}
+			cute.WithQueryKV({{.Name}}, {{.Value}}),
+			{{- end}}
+			{{- range .Headers}}
+			cute.WithHeadersKV({{.Name}}, {{.Value}}),
+			{{- end}}
+			{{- if .Body}}
+			cute.WithBody([]byte({{.Body}})),
+			{{- end}}
+		).
+		{{- if .Status}}
+		ExpectStatus({{.Status}}).
+		{{- end}}
+		{{- if .Schema}}
+		ExpectJSONSchemaString({{.Schema}}).
+		{{- end}}
+		ExecuteTest(context.Background(), t)
+}
+{{end}}`))
+
+type config struct {
+	spec    string
+	pkg     string
+	baseURL string
+}
+
+type testFile struct {
+	Spec    string
+	Package string
+	BaseURL string
+	Tests   []testCase
+}
+
+// testCase contains Go literals for template
+type testCase struct {
+	Name        string
+	Method      string
+	Path        string
+	Responses   string
+	Todo        []string
+	Title       string
+	Description string
+	Tags        string
+	Feature     string
+	Story       string
+	URI         string
+	MethodConst string
+	Query       []keyValue
+	Headers     []keyValue
+	Body        string
+	Status      string
+	Schema      string
+}
+
+type keyValue struct {
+	Name  string
+	Value string
+}
+
+// generate returns source of test file with test for every operation of document
+func generate(doc *openapi.Document, cfg config) ([]byte, error) {
+	operations, err := doc.Operations()
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := cfg.baseURL
+	if baseURL == "" && len(doc.Servers) != 0 {
+		baseURL = doc.Servers[0].URL
+	}
+
+	file := testFile{
+		Spec:    cfg.spec,
+		Package: cfg.pkg,
+		BaseURL: codegen.GoString(strings.TrimSuffix(baseURL, "/")),
+		Tests:   make([]testCase, 0, len(operations)),
+	}
+
+	names := make(map[string]bool)
+
+	for _, op := range operations {
+		tc, err := newTestCase(doc, op)
+		if err != nil {
+			return nil, fmt.Errorf("could not generate test for %v %v error: '%s'", op.Method, op.Path, err)
+		}
+
+		tc.Name = uniqueName(names, tc.Name)
+		file.Tests = append(file.Tests, tc)
+	}
+
+	var buf bytes.Buffer
+	if err = testFileTemplate.Execute(&buf, file); err != nil {
+		return nil, err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("could not format generated code error: '%s'", err)
+	}
+
+	return src, nil
+}
+
+func newTestCase(doc *openapi.Document, op openapi.MethodOperation) (testCase, error) {
+	tc := testCase{
+		Name:        testName(op),
+		Method:      op.Method,
+		Path:        op.Path,
+		Title:       codegen.GoString(op.Method + " " + op.Path),
+		MethodConst: codegen.MethodName(op.Method),
+		Query:       make([]keyValue, 0),
+		Headers:     make([]keyValue, 0),
+		Todo:        make([]string, 0),
+	}
+
+	if op.Description != "" {
+		tc.Description = codegen.GoString(op.Description)
+	}
+
+	if len(op.Tags) != 0 {
+		tags := make([]string, 0, len(op.Tags))
+		for _, tag := range op.Tags {
+			tags = append(tags, codegen.GoString(tag))
+		}
+
+		tc.Tags = strings.Join(tags, ", ")
+		tc.Feature = codegen.GoString(op.Tags[0])
+	}
+
+	switch {
+	case op.Summary != "":
+		tc.Story = codegen.GoString(op.Summary)
+	case op.OperationID != "":
+		tc.Story = codegen.GoString(op.OperationID)
+	}
+
+	tc.URI = codegen.GoString(requestPath(doc, op, &tc))
+
+	if err := addParameters(doc, op, &tc); err != nil {
+		return tc, err
+	}
+
+	if err := addBody(doc, op, &tc); err != nil {
+		return tc, err
+	}
+
+	if err := addResponse(doc, op, &tc); err != nil {
+		return tc, err
+	}
+
+	return tc, nil
+}
+
+// requestPath returns path with values of path parameters
+func requestPath(doc *openapi.Document, op openapi.MethodOperation, tc *testCase) string {
+	p := op.Path
+
+	for _, param := range op.Parameters {
+		if param.In != "path" {
+			continue
+		}
+
+		value, ok := parameterValue(doc, param)
+		if !ok {
+			tc.Todo = append(tc.Todo, fmt.Sprintf("set value of path parameter %v", param.Name))
+		}
+
+		p = strings.ReplaceAll(p, "{"+param.Name+"}", url.PathEscape(value))
+	}
+
+	if strings.Contains(p, "{") {
+		tc.Todo = append(tc.Todo, "set values of path parameters, which are not documented")
+	}
+
+	return p
+}
+
+func addParameters(doc *openapi.Document, op openapi.MethodOperation, tc *testCase) error {
+	cookies := make([]string, 0)
+
+	for _, param := range op.Parameters {
+		if param.In == "path" {
+			continue
+		}
+
+		value, hasValue := parameterValue(doc, param)
+
+		// optional parameters without example are skipped
+		if !param.Required && !hasValue {
+			continue
+		}
+
+		if !hasValue {
+			tc.Todo = append(tc.Todo, fmt.Sprintf("set value of %v parameter %v", param.In, param.Name))
+		}
+
+		switch param.In {
+		case "query":
+			tc.Query = append(tc.Query, keyValue{Name: codegen.GoString(param.Name), Value: codegen.GoString(value)})
+		case "header":
+			tc.Headers = append(tc.Headers, keyValue{Name: codegen.GoString(param.Name), Value: codegen.GoString(value)})
+		case "cookie":
+			cookies = append(cookies, param.Name+"="+value)
+		default:
+			return fmt.Errorf("unknown location %v of parameter %v", param.In, param.Name)
+		}
+	}
+
+	if len(cookies) != 0 {
+		tc.Headers = append(tc.Headers, keyValue{Name: codegen.GoString("Cookie"), Value: codegen.GoString(strings.Join(cookies, "; "))})
+	}
+
+	return nil
+}
+
+// parameterValue returns example of parameter or placeholder by type
+func parameterValue(doc *openapi.Document, param openapi.Parameter) (string, bool) {
+	if v, ok := doc.ExampleValue(param.Example, param.Examples, param.Schema); ok {
+		switch value := v.(type) {
+		case []interface{}:
+			items := make([]string, 0, len(value))
+			for _, item := range value {
+				items = append(items, fmt.Sprint(item))
+			}
+
+			return strings.Join(items, ","), true
+		default:
+			return fmt.Sprint(value), true
+		}
+	}
+
+	switch doc.SchemaType(param.Schema) {
+	case "integer", "number":
+		return "1", false
+	case "boolean":
+		return "true", false
+	default:
+		return param.Name, false
+	}
+}
+
+func addBody(doc *openapi.Document, op openapi.MethodOperation, tc *testCase) error {
+	body, err := doc.RequestBody(op.RequestBody)
+	if err != nil || body == nil {
+		return err
+	}
+
+	mediaType, ok := openapi.JSONMediaType(body.Content)
+	if !ok {
+		if body.Required {
+			tc.Todo = append(tc.Todo, "set request body")
+		}
+
+		return nil
+	}
+
+	value, ok := doc.ExampleValue(mediaType.Example, mediaType.Examples, mediaType.Schema)
+	if !ok {
+		if !body.Required {
+			return nil
+		}
+
+		value = doc.SampleValue(mediaType.Schema)
+		tc.Todo = append(tc.Todo, "check request body, it is generated from schema")
+	}
+
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not marshal example of request body error: '%s'", err)
+	}
+
+	tc.Headers = append(tc.Headers, keyValue{Name: codegen.GoString("Content-Type"), Value: codegen.GoString("application/json")})
+	tc.Body = codegen.GoRawString(string(data))
+
+	return nil
+}
+
+// addResponse adds status and schema of success response
+// First 2xx response is success response, other responses are only documented.
+func addResponse(doc *openapi.Document, op openapi.MethodOperation, tc *testCase) error {
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		codes = append(codes, code)
+	}
+
+	sort.Strings(codes)
+
+	tc.Responses = strings.Join(codes, ", ")
+
+	var success string
+
+	for _, code := range codes {
+		if strings.HasPrefix(code, "2") {
+			success = code
+
+			break
+		}
+	}
+
+	if success == "" {
+		tc.Todo = append(tc.Todo, "success response is not documented, set expected status")
+
+		return nil
+	}
+
+	if status, err := strconv.Atoi(success); err == nil {
+		tc.Status = codegen.StatusName(status)
+	}
+
+	response, err := doc.Response(op.Responses[success])
+	if err != nil {
+		return err
+	}
+
+	mediaType, ok := openapi.JSONMediaType(response.Content)
+	if !ok || mediaType.Schema == nil {
+		return nil
+	}
+
+	schema, err := doc.JSONSchema(mediaType.Schema)
+	if err != nil {
+		return err
+	}
+
+	var pretty bytes.Buffer
+	if err = json.Indent(&pretty, schema, "", "  "); err != nil {
+		return err
+	}
+
+	tc.Schema = codegen.GoRawString(pretty.String())
+
+	return nil
+}
+
+// testName returns name of test from operationId or from method and path
+func testName(op openapi.MethodOperation) string {
+	source := op.OperationID
+	if source == "" {
+		source = strings.ToLower(op.Method) + " " + op.Path
+	}
+
+	var (
+		b     strings.Builder
+		upper = true
+	)
+
+	b.WriteString("Test")
+
+	for _, r := range source {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+
+			continue
+		}
+
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+func uniqueName(names map[string]bool, name string) string {
+	res := name
+
+	for i := 2; names[res]; i++ {
+		res = fmt.Sprintf("%v%v", name, i)
+	}
+
+	names[res] = true
+
+	return res
+}
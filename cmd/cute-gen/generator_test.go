@@ -0,0 +1,110 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/cute/internal/openapi"
+)
+
+const spec = `{
+  "openapi": "3.0.0",
+  "info": {"title": "Users", "version": "1"},
+  "servers": [{"url": "https://users.example.com/api/"}],
+  "paths": {
+    "/users/{id}": {
+      "get": {
+        "operationId": "get-user",
+        "summary": "Get user",
+        "tags": ["users", "read"],
+        "parameters": [
+          {"name": "id", "in": "path", "required": true, "schema": {"type": "integer"}, "example": 7},
+          {"name": "expand", "in": "query", "schema": {"type": "string"}, "example": "roles"},
+          {"name": "debug", "in": "query", "schema": {"type": "boolean"}},
+          {"name": "session", "in": "cookie", "required": true, "schema": {"type": "string"}}
+        ],
+        "responses": {
+          "200": {
+            "description": "user",
+            "content": {"application/json": {"schema": {
+              "type": "object",
+              "required": ["id"],
+              "properties": {"id": {"type": "integer"}, "note": {"type": "string", "example": "with ` + "`" + `backtick` + "`" + `"}}
+            }}}
+          },
+          "404": {"description": "not found"}
+        }
+      }
+    },
+    "/users": {
+      "post": {
+        "tags": ["users"],
+        "requestBody": {
+          "content": {"application/json": {"example": {"name": "cute"}}}
+        },
+        "responses": {"201": {"description": "created"}, "default": {"description": "error"}}
+      }
+    }
+  }
+}`
+
+func TestGenerate(t *testing.T) {
+	doc, err := openapi.Parse([]byte(spec))
+	require.NoError(t, err)
+
+	src, err := generate(doc, config{spec: "users.json", pkg: "users"})
+	require.NoError(t, err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), "users_test.go", src, parser.AllErrors)
+	require.NoError(t, err)
+
+	code := string(src)
+
+	require.Contains(t, code, "package users")
+	require.Contains(t, code, `const baseURL = "https://users.example.com/api"`)
+
+	require.Contains(t, code, "func TestGetUser(t *testing.T)")
+	require.Contains(t, code, "// Documented responses: 200, 404")
+	require.Contains(t, code, "// TODO: set value of cookie parameter session")
+	require.Contains(t, code, `Tags("users", "read")`)
+	require.Contains(t, code, `Feature("users")`)
+	require.Contains(t, code, `Story("Get user")`)
+	require.NotContains(t, code, `Description(`)
+	require.Contains(t, code, `cute.WithURI(baseURL+"/users/7")`)
+	require.Contains(t, code, `cute.WithQueryKV("expand", "roles")`)
+	require.NotContains(t, code, `"debug"`)
+	require.Contains(t, code, `cute.WithHeadersKV("Cookie", "session=session")`)
+	require.Contains(t, code, "ExpectStatus(http.StatusOK)")
+	require.Contains(t, code, `ExpectJSONSchemaString("{\n  \"$schema\"`)
+
+	require.Contains(t, code, "func TestPostUsers(t *testing.T)")
+	require.Contains(t, code, "cute.WithMethod(http.MethodPost)")
+	require.Contains(t, code, "cute.WithBody([]byte(`{\n  \"name\": \"cute\"\n}`))")
+	require.Contains(t, code, `cute.WithHeadersKV("Content-Type", "application/json")`)
+	require.Contains(t, code, "ExpectStatus(http.StatusCreated)")
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+
+	specPath := filepath.Join(dir, "users.json")
+	require.NoError(t, os.WriteFile(specPath, []byte(spec), 0600))
+
+	outDir := filepath.Join(dir, "usersapi")
+	require.NoError(t, os.Mkdir(outDir, 0755))
+
+	out := filepath.Join(outDir, "users_test.go")
+	require.NoError(t, run(specPath, out, "", "http://localhost:8080"))
+
+	src, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.Contains(t, string(src), "package usersapi")
+	require.Contains(t, string(src), `const baseURL = "http://localhost:8080"`)
+
+	require.Error(t, run("", out, "", ""))
+}
@@ -0,0 +1,67 @@
+// Command cute-gen generates cute tests from OpenAPI 3 document.
+//
+// Usage:
+//
+//	cute-gen -spec openapi.yaml -out api_test.go -package api
+//
+// Every operation gets a test with request from parameters and examples,
+// expected status and JSON schema of success response and Allure labels from tags.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ozontech/cute/internal/codegen"
+	"github.com/ozontech/cute/internal/openapi"
+)
+
+func main() {
+	var (
+		spec    = flag.String("spec", "", "path to OpenAPI 3 document in JSON or YAML")
+		out     = flag.String("out", "api_test.go", "path to generated file, - for stdout")
+		pkg     = flag.String("package", "", "package of generated file, default is name of output directory")
+		baseURL = flag.String("base-url", "", "base URL of requests, default is first server of document")
+	)
+
+	flag.Parse()
+
+	if err := run(*spec, *out, *pkg, *baseURL); err != nil {
+		fmt.Fprintf(os.Stderr, "cute-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(spec, out, pkg, baseURL string) error {
+	if spec == "" {
+		return fmt.Errorf("flag -spec is required")
+	}
+
+	doc, err := openapi.Load(spec)
+	if err != nil {
+		return err
+	}
+
+	if pkg == "" {
+		pkg = codegen.PackageName(out)
+	}
+
+	src, err := generate(doc, config{
+		spec:    filepath.Base(spec),
+		pkg:     pkg,
+		baseURL: baseURL,
+	})
+	if err != nil {
+		return err
+	}
+
+	if out == "-" {
+		_, err = os.Stdout.Write(src)
+
+		return err
+	}
+
+	return os.WriteFile(out, src, 0600)
+}
@@ -0,0 +1,490 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/ozontech/cute/internal/codegen"
+	"github.com/ozontech/cute/internal/postman"
+)
+
+var variablePattern = regexp.MustCompile(`\{\{[^{}]+\}\}`)
+
+// expandHelper is added to generated file, if variables are used
+const expandHelper = `
+var variablePattern = regexp.MustCompile(` + "`\\{\\{[^{}]+\\}\\}`" + `)
+
+// expand replaces {{name}} by variable of collection or environment.
+// Environment variable of process with the same name overrides value.
+func expand(s string) string {
+	return variablePattern.ReplaceAllStringFunc(s, func(v string) string {
+		name := strings.Trim(v, "{}")
+
+		if value, ok := os.LookupEnv(name); ok {
+			return value
+		}
+
+		if value, ok := variables[name]; ok {
+			return value
+		}
+
+		return v
+	})
+}
+`
+
+type config struct {
+	source string
+	pkg    string
+}
+
+// report contains scripts and requests, which could not be translated
+type report struct {
+	Untranslated []string
+}
+
+type generator struct {
+	collection *postman.Collection
+	variables  map[string]string
+	imports    map[string]bool
+	names      map[string]bool
+	tests      bytes.Buffer
+	report     *report
+}
+
+// generate returns source of test file with test for every request of collection
+func generate(collection *postman.Collection, variables map[string]string, cfg config) ([]byte, *report, error) {
+	g := &generator{
+		collection: collection,
+		variables:  variables,
+		imports: map[string]bool{
+			"context":                  true,
+			"net/http":                 true,
+			"testing":                  true,
+			"github.com/ozontech/cute": true,
+		},
+		names:  make(map[string]bool),
+		report: &report{Untranslated: make([]string, 0)},
+	}
+
+	for _, item := range collection.Item {
+		if err := g.item(item, nil, collection.Event, collection.Auth); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	src, err := g.file(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return src, g.report, nil
+}
+
+// item generates tests for request or for all requests of folder
+// Events and auth of folders are inherited by requests.
+func (g *generator) item(item postman.Item, folders []string, events []postman.Event, auth *postman.Auth) error {
+	events = append(append([]postman.Event{}, events...), item.Event...)
+
+	if item.Auth != nil {
+		auth = item.Auth
+	}
+
+	if item.IsFolder() {
+		for _, child := range item.Item {
+			if err := g.item(child, append(append([]string{}, folders...), item.Name), events, auth); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return g.request(item, folders, events, auth)
+}
+
+func (g *generator) request(item postman.Item, folders []string, events []postman.Event, auth *postman.Auth) error {
+	var (
+		req      = item.Request
+		fullName = strings.Join(append(append([]string{}, folders...), item.Name), " / ")
+		name     = g.testName(append(append([]string{}, folders...), item.Name))
+		todo     = make([]string, 0)
+	)
+
+	if req.Auth != nil {
+		auth = req.Auth
+	}
+
+	testLines := make([]string, 0)
+
+	for _, event := range events {
+		switch event.Listen {
+		case "test":
+			testLines = append(testLines, event.Script.Exec...)
+		case "prerequest":
+			for _, line := range event.Script.Exec {
+				if strings.TrimSpace(line) != "" {
+					todo = append(todo, "pre-request script: "+strings.TrimSpace(line))
+				}
+			}
+		}
+	}
+
+	checks := translateScript(testLines)
+	for _, line := range checks.untranslated {
+		todo = append(todo, "test script: "+line)
+	}
+
+	for imp := range checks.imports {
+		g.imports[imp] = true
+	}
+
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "\n// %v is generated from request %v\n", name, strconv.Quote(fullName))
+
+	for _, t := range todo {
+		fmt.Fprintf(&b, "// TODO: %v\n", t)
+		g.report.Untranslated = append(g.report.Untranslated, fmt.Sprintf("%v: %v", fullName, t))
+	}
+
+	fmt.Fprintf(&b, "func %v(t *testing.T) {\n", name)
+	fmt.Fprintf(&b, "cute.NewTestBuilder().\n")
+	fmt.Fprintf(&b, "Title(%v).\n", codegen.GoString(item.Name))
+
+	description := item.Description
+	if description == "" {
+		description = req.Description
+	}
+
+	if description != "" {
+		fmt.Fprintf(&b, "Description(%v).\n", codegen.GoString(string(description)))
+	}
+
+	if g.collection.Info.Name != "" {
+		fmt.Fprintf(&b, "AddParentSuite(%v).\n", codegen.GoString(g.collection.Info.Name))
+	}
+
+	if len(folders) != 0 {
+		fmt.Fprintf(&b, "AddSuiteLabel(%v).\n", codegen.GoString(folders[0]))
+	}
+
+	if len(folders) > 1 {
+		fmt.Fprintf(&b, "AddSubSuite(%v).\n", codegen.GoString(strings.Join(folders[1:], " / ")))
+	}
+
+	fmt.Fprintf(&b, "Create().\n")
+	fmt.Fprintf(&b, "RequestBuilder(\n")
+
+	if err := g.requestBuilders(&b, req, auth); err != nil {
+		return fmt.Errorf("could not generate request %v error: '%s'", fullName, err)
+	}
+
+	fmt.Fprintf(&b, ").\n")
+
+	if checks.timeout != 0 {
+		g.imports["time"] = true
+
+		fmt.Fprintf(&b, "ExpectExecuteTimeout(%v*time.Millisecond).\n", checks.timeout)
+	}
+
+	if checks.status != 0 {
+		fmt.Fprintf(&b, "ExpectStatus(%v).\n", codegen.StatusName(checks.status))
+	}
+
+	if len(checks.body) != 0 {
+		fmt.Fprintf(&b, "AssertBody(\n%v,\n).\n", strings.Join(checks.body, ",\n"))
+	}
+
+	if len(checks.headers) != 0 {
+		fmt.Fprintf(&b, "AssertHeaders(\n%v,\n).\n", strings.Join(checks.headers, ",\n"))
+	}
+
+	fmt.Fprintf(&b, "ExecuteTest(context.Background(), t)\n}\n")
+
+	g.tests.Write(b.Bytes())
+
+	return nil
+}
+
+func (g *generator) requestBuilders(b *bytes.Buffer, req *postman.Request, auth *postman.Auth) error {
+	method := strings.ToUpper(req.Method)
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	methodName := codegen.MethodName(method)
+
+	fmt.Fprintf(b, "cute.WithURI(%v),\n", g.value(req.URL.String()))
+	fmt.Fprintf(b, "cute.WithMethod(%v),\n", methodName)
+
+	headers := make(map[string]bool)
+
+	for _, h := range req.Header {
+		if h.Disabled {
+			continue
+		}
+
+		headers[strings.ToLower(h.Key)] = true
+
+		fmt.Fprintf(b, "cute.WithHeadersKV(%v, %v),\n", codegen.GoString(h.Key), g.value(h.Value))
+	}
+
+	if auth != nil && !headers["authorization"] {
+		g.auth(b, auth)
+	}
+
+	if req.Body == nil || req.Body.Disabled {
+		return nil
+	}
+
+	return g.body(b, req.Body, headers)
+}
+
+func (g *generator) auth(b *bytes.Buffer, auth *postman.Auth) {
+	switch auth.Type {
+	case "bearer":
+		fmt.Fprintf(b, "cute.WithHeadersKV(\"Authorization\", %v),\n", g.value("Bearer "+auth.Param("token")))
+	case "basic":
+		credentials := auth.Param("username") + ":" + auth.Param("password")
+
+		if !variablePattern.MatchString(credentials) {
+			fmt.Fprintf(b, "cute.WithHeadersKV(\"Authorization\", %v),\n",
+				codegen.GoString("Basic "+base64.StdEncoding.EncodeToString([]byte(credentials))))
+
+			return
+		}
+
+		g.imports["encoding/base64"] = true
+
+		fmt.Fprintf(b, "cute.WithHeadersKV(\"Authorization\", \"Basic \"+base64.StdEncoding.EncodeToString([]byte(%v))),\n", g.value(credentials))
+	case "apikey":
+		if auth.Param("in") == "query" {
+			fmt.Fprintf(b, "cute.WithQueryKV(%v, %v),\n", g.value(auth.Param("key")), g.value(auth.Param("value")))
+
+			return
+		}
+
+		fmt.Fprintf(b, "cute.WithHeadersKV(%v, %v),\n", g.value(auth.Param("key")), g.value(auth.Param("value")))
+	case "noauth", "":
+	default:
+		g.report.Untranslated = append(g.report.Untranslated, fmt.Sprintf("auth %v is not supported", auth.Type))
+	}
+}
+
+func (g *generator) body(b *bytes.Buffer, body *postman.Body, headers map[string]bool) error {
+	switch body.Mode {
+	case "raw":
+		if body.Language() == "json" && !headers["content-type"] {
+			fmt.Fprintf(b, "cute.WithHeadersKV(\"Content-Type\", \"application/json\"),\n")
+		}
+
+		fmt.Fprintf(b, "cute.WithBody([]byte(%v)),\n", g.rawValue(body.Raw))
+	case "urlencoded":
+		fields := make([]string, 0, len(body.URLEncoded))
+
+		for _, field := range body.URLEncoded {
+			if !field.Disabled {
+				fields = append(fields, queryEscape(field.Key)+"="+queryEscape(field.Value))
+			}
+		}
+
+		if !headers["content-type"] {
+			fmt.Fprintf(b, "cute.WithHeadersKV(\"Content-Type\", \"application/x-www-form-urlencoded\"),\n")
+		}
+
+		fmt.Fprintf(b, "cute.WithBody([]byte(%v)),\n", g.value(strings.Join(fields, "&")))
+	case "formdata":
+		for _, field := range body.FormData {
+			if field.Disabled {
+				continue
+			}
+
+			if field.Type == "file" {
+				fmt.Fprintf(b, "cute.WithFileFormKV(%v, &cute.File{Path: %v}),\n", codegen.GoString(field.Key), codegen.GoString(field.File()))
+
+				continue
+			}
+
+			fmt.Fprintf(b, "cute.WithFormKV(%v, []byte(%v)),\n", codegen.GoString(field.Key), g.value(field.Value))
+		}
+	case "graphql":
+		if body.GraphQL == nil {
+			return nil
+		}
+
+		payload := map[string]interface{}{"query": body.GraphQL.Query}
+
+		if vars := strings.TrimSpace(body.GraphQL.Variables); vars != "" {
+			payload["variables"] = json.RawMessage(vars)
+		}
+
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("could not marshal graphql body error: '%s'", err)
+		}
+
+		if !headers["content-type"] {
+			fmt.Fprintf(b, "cute.WithHeadersKV(\"Content-Type\", \"application/json\"),\n")
+		}
+
+		fmt.Fprintf(b, "cute.WithBody([]byte(%v)),\n", g.rawValue(string(data)))
+	case "":
+	default:
+		g.report.Untranslated = append(g.report.Untranslated, fmt.Sprintf("body mode %v is not supported", body.Mode))
+	}
+
+	return nil
+}
+
+// value returns Go expression of string with variables
+func (g *generator) value(s string) string {
+	if !variablePattern.MatchString(s) {
+		return codegen.GoString(s)
+	}
+
+	return fmt.Sprintf("expand(%v)", codegen.GoString(s))
+}
+
+// rawValue returns Go expression of multiline string with variables
+func (g *generator) rawValue(s string) string {
+	if !variablePattern.MatchString(s) {
+		return codegen.GoRawString(s)
+	}
+
+	return fmt.Sprintf("expand(%v)", codegen.GoRawString(s))
+}
+
+func (g *generator) file(cfg config) ([]byte, error) {
+	var (
+		b       bytes.Buffer
+		tests   = g.tests.String()
+		expand  = strings.Contains(tests, "expand(")
+		imports = make([]string, 0, len(g.imports))
+	)
+
+	if expand {
+		g.imports["os"] = true
+		g.imports["regexp"] = true
+		g.imports["strings"] = true
+	}
+
+	for imp := range g.imports {
+		imports = append(imports, imp)
+	}
+
+	sort.Slice(imports, func(i, j int) bool {
+		iThird, jThird := strings.Contains(imports[i], "."), strings.Contains(imports[j], ".")
+		if iThird != jThird {
+			return jThird
+		}
+
+		return imports[i] < imports[j]
+	})
+
+	fmt.Fprintf(&b, "// Tests are generated by cute-postman from %v.\n", cfg.source)
+	fmt.Fprintf(&b, "// Check TODO comments, scripts from them are not translated.\n\n")
+	fmt.Fprintf(&b, "package %v\n\nimport (\n", cfg.pkg)
+
+	for i, imp := range imports {
+		if i > 0 && strings.Contains(imp, ".") && !strings.Contains(imports[i-1], ".") {
+			b.WriteString("\n")
+		}
+
+		fmt.Fprintf(&b, "%v\n", strconv.Quote(imp))
+	}
+
+	b.WriteString(")\n")
+
+	if expand {
+		names := make([]string, 0, len(g.variables))
+		for name := range g.variables {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		b.WriteString("\n// variables of collection and environment\nvar variables = map[string]string{\n")
+
+		for _, name := range names {
+			fmt.Fprintf(&b, "%v: %v,\n", codegen.GoString(name), codegen.GoString(g.variables[name]))
+		}
+
+		b.WriteString("}\n")
+		b.WriteString(expandHelper)
+	}
+
+	b.WriteString(tests)
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("could not format generated code error: '%s'", err)
+	}
+
+	return src, nil
+}
+
+// testName returns unique name of test from folders and name of request
+func (g *generator) testName(parts []string) string {
+	var (
+		b     strings.Builder
+		upper = true
+	)
+
+	b.WriteString("Test")
+
+	for _, r := range strings.Join(parts, " ") {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+
+			continue
+		}
+
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+
+		b.WriteRune(r)
+	}
+
+	name := b.String()
+	res := name
+
+	for i := 2; g.names[res]; i++ {
+		res = fmt.Sprintf("%v%v", name, i)
+	}
+
+	g.names[res] = true
+
+	return res
+}
+
+// queryEscape escapes value of urlencoded body, but keeps variables
+func queryEscape(s string) string {
+	var (
+		b    strings.Builder
+		last int
+	)
+
+	for _, loc := range variablePattern.FindAllStringIndex(s, -1) {
+		b.WriteString(url.QueryEscape(s[last:loc[0]]))
+		b.WriteString(s[loc[0]:loc[1]])
+
+		last = loc[1]
+	}
+
+	b.WriteString(url.QueryEscape(s[last:]))
+
+	return b.String()
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const collection = `{
+  "info": {"name": "Shop", "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"},
+  "auth": {"type": "bearer", "bearer": [{"key": "token", "value": "{{token}}"}]},
+  "variable": [{"key": "baseUrl", "value": "https://shop.com"}],
+  "item": [
+    {
+      "name": "orders",
+      "item": [
+        {
+          "name": "admin",
+          "item": [
+            {
+              "name": "Create order",
+              "event": [
+                {"listen": "prerequest", "script": {"exec": ["pm.environment.set(\"ts\", Date.now());"]}},
+                {"listen": "test", "script": {"exec": [
+                  "pm.test(\"created\", function () {",
+                  "    pm.response.to.have.status(201);",
+                  "    var body = pm.response.json();",
+                  "    pm.expect(body.id).to.exist;",
+                  "    pm.expect(body.items).to.have.lengthOf(1);",
+                  "    postman.setNextRequest(null);",
+                  "});"
+                ]}}
+              ],
+              "request": {
+                "method": "POST",
+                "header": [{"key": "X-Id", "value": "1"}, {"key": "X-Old", "value": "1", "disabled": true}],
+                "url": {"raw": "{{baseUrl}}/orders", "host": ["{{baseUrl}}"], "path": ["orders"]},
+                "body": {"mode": "raw", "raw": "{\"items\": [1]}", "options": {"raw": {"language": "json"}}}
+              }
+            }
+          ]
+        },
+        {
+          "name": "Upload",
+          "request": {
+            "method": "PUT",
+            "auth": {"type": "basic", "basic": [{"key": "username", "value": "user"}, {"key": "password", "value": "pass"}]},
+            "url": "https://shop.com/upload",
+            "body": {"mode": "formdata", "formdata": [
+              {"key": "file", "type": "file", "src": "/tmp/a.png"},
+              {"key": "name", "type": "text", "value": "a"}
+            ]}
+          }
+        }
+      ]
+    },
+    {
+      "name": "Login",
+      "request": {
+        "method": "POST",
+        "auth": {"type": "noauth"},
+        "url": "https://shop.com/login",
+        "body": {"mode": "urlencoded", "urlencoded": [{"key": "user", "value": "a b"}, {"key": "password", "value": "{{password}}"}]}
+      }
+    }
+  ]
+}`
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "shop.json")
+	out := filepath.Join(dir, "shop_test.go")
+
+	require.NoError(t, os.WriteFile(path, []byte(collection), 0600))
+
+	var stderr bytes.Buffer
+
+	require.NoError(t, run(path, "", out, "shop", nil, &stderr))
+
+	src, err := os.ReadFile(out)
+	require.NoError(t, err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), out, src, parser.AllErrors)
+	require.NoError(t, err)
+
+	code := string(src)
+	require.Contains(t, code, "package shop")
+	require.Contains(t, code, `"baseUrl": "https://shop.com",`)
+	require.Contains(t, code, "func expand(s string) string")
+
+	require.Contains(t, code, "func TestOrdersAdminCreateOrder(t *testing.T)")
+	require.Contains(t, code, `AddParentSuite("Shop").`)
+	require.Contains(t, code, `AddSuiteLabel("orders").`)
+	require.Contains(t, code, `AddSubSuite("admin").`)
+	require.Contains(t, code, `cute.WithURI(expand("{{baseUrl}}/orders")),`)
+	require.Contains(t, code, `cute.WithMethod(http.MethodPost),`)
+	require.Contains(t, code, `cute.WithHeadersKV("X-Id", "1"),`)
+	require.NotContains(t, code, "X-Old")
+	require.Contains(t, code, `cute.WithHeadersKV("Authorization", expand("Bearer {{token}}")),`)
+	require.Contains(t, code, `cute.WithHeadersKV("Content-Type", "application/json"),`)
+	require.Contains(t, code, "cute.WithBody([]byte(`{\"items\": [1]}`)),")
+	require.Contains(t, code, `ExpectStatus(http.StatusCreated).`)
+	require.Contains(t, code, `json.Present("$.id"),`)
+	require.Contains(t, code, `json.Length("$.items", 1),`)
+	require.Contains(t, code, `// TODO: pre-request script: pm.environment.set("ts", Date.now());`)
+	require.Contains(t, code, `// TODO: test script: postman.setNextRequest(null)`)
+
+	require.Contains(t, code, "func TestOrdersUpload(t *testing.T)")
+	require.Contains(t, code, `cute.WithHeadersKV("Authorization", "Basic dXNlcjpwYXNz"),`)
+	require.Contains(t, code, `cute.WithFileFormKV("file", &cute.File{Path: "/tmp/a.png"}),`)
+	require.Contains(t, code, `cute.WithFormKV("name", []byte("a")),`)
+
+	require.Contains(t, code, "func TestLogin(t *testing.T)")
+	require.Contains(t, code, `cute.WithHeadersKV("Content-Type", "application/x-www-form-urlencoded"),`)
+	require.Contains(t, code, `cute.WithBody([]byte(expand("user=a+b&password={{password}}"))),`)
+
+	require.Contains(t, stderr.String(), "untranslated: orders / admin / Create order: test script: postman.setNextRequest(null)")
+}
+
+func TestGenerateEnvironment(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "shop.json")
+	env := filepath.Join(dir, "dev.json")
+
+	require.NoError(t, os.WriteFile(path, []byte(collection), 0600))
+	require.NoError(t, os.WriteFile(env, []byte(`{"name": "dev", "values": [{"key": "baseUrl", "value": "https://dev.shop.com"}]}`), 0600))
+
+	var stdout bytes.Buffer
+
+	require.NoError(t, run(path, env, "-", "", &stdout, &bytes.Buffer{}))
+	require.Contains(t, stdout.String(), "package api")
+	require.Contains(t, stdout.String(), `"baseUrl": "https://dev.shop.com",`)
+}
+
+func TestRunError(t *testing.T) {
+	require.Error(t, run("", "", "-", "", nil, nil))
+	require.Error(t, run("not_exists.json", "", "-", "", nil, nil))
+}
@@ -0,0 +1,84 @@
+// Command cute-postman generates cute tests from Postman v2.1 collection.
+//
+// Usage:
+//
+//	cute-postman -collection api.postman_collection.json -environment dev.postman_environment.json -out api_test.go
+//
+// Every request gets a test, folders are mapped to Allure suites,
+// variables of collection and environment are expanded at runtime and could be overridden by environment variables of process.
+// Common pm.test checks of status, headers and JSON body are translated to asserts,
+// other scripts are reported to stderr and left as TODO comments.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/ozontech/cute/internal/codegen"
+	"github.com/ozontech/cute/internal/postman"
+)
+
+func main() {
+	var (
+		collection  = flag.String("collection", "", "path to Postman v2.1 collection")
+		environment = flag.String("environment", "", "path to Postman environment")
+		out         = flag.String("out", "postman_test.go", "path to generated file, - for stdout")
+		pkg         = flag.String("package", "", "package of generated file, default is name of output directory")
+	)
+
+	flag.Parse()
+
+	if err := run(*collection, *environment, *out, *pkg, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "cute-postman: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(collectionPath, environmentPath, out, pkg string, stdout, stderr io.Writer) error {
+	if collectionPath == "" {
+		return fmt.Errorf("flag -collection is required")
+	}
+
+	collection, err := postman.LoadCollection(collectionPath)
+	if err != nil {
+		return err
+	}
+
+	envs := make([]*postman.Environment, 0, 1)
+
+	if environmentPath != "" {
+		env, err := postman.LoadEnvironment(environmentPath)
+		if err != nil {
+			return err
+		}
+
+		envs = append(envs, env)
+	}
+
+	if pkg == "" {
+		pkg = codegen.PackageName(out)
+	}
+
+	src, rep, err := generate(collection, postman.Variables(collection, envs...), config{
+		source: filepath.Base(collectionPath),
+		pkg:    pkg,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, line := range rep.Untranslated {
+		fmt.Fprintf(stderr, "untranslated: %v\n", line)
+	}
+
+	if out == "-" {
+		_, err = stdout.Write(src)
+
+		return err
+	}
+
+	return os.WriteFile(out, src, 0600)
+}
@@ -0,0 +1,410 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ozontech/cute/internal/codegen"
+)
+
+var (
+	skipLine       = regexp.MustCompile(`^(pm\.test\(.*(function\s*\(\)|=>)\s*\{|\}\)|\}|\{|)$`)
+	jsonAlias      = regexp.MustCompile(`^(?:var|let|const)\s+([A-Za-z_$][\w$]*)\s*=\s*pm\.response\.json\(\)$`)
+	statusLine     = regexp.MustCompile(`^pm\.response\.to\.have\.status\((\d{3})\)$`)
+	statusExpect   = regexp.MustCompile(`^pm\.expect\(pm\.response\.code\)\.to\.(?:be\.)?(?:eql|equal)\((\d{3})\)$`)
+	statusOK       = regexp.MustCompile(`^pm\.response\.to\.be\.ok$`)
+	responseTime   = regexp.MustCompile(`^pm\.expect\(pm\.response\.responseTime\)\.to\.be\.(?:below|lessThan)\((\d+)\)$`)
+	headerPresent  = regexp.MustCompile(`^pm\.response\.to\.have\.header\((.+)\)$`)
+	textInclude    = regexp.MustCompile(`^pm\.expect\(pm\.response\.text\(\)\)\.to\.(?:include|contain)\((.+)\)$`)
+	jsonBodyPath   = regexp.MustCompile(`^pm\.response\.to\.have\.jsonBody\((.+)\)$`)
+	expectLine     = regexp.MustCompile(`^pm\.expect\(([^()]*(?:\(\))?[^()]*)\)\.(.+)$`)
+	jsPath         = regexp.MustCompile(`^((\.[A-Za-z_$][\w$]*)|(\[\d+\])|(\['[^']*'\])|(\["[^"]*"\]))*$`)
+	chainWithArg   = regexp.MustCompile(`^([\w.]+)\((.*)\)$`)
+	variableGetter = regexp.MustCompile(`^pm\.(?:environment|variables|collectionVariables|globals)\.get\((['"])([^'"]+)['"]\)$`)
+	bareKey        = regexp.MustCompile(`([{,]\s*)([A-Za-z_$][\w$]*)\s*:`)
+)
+
+var jsonTypes = map[string]string{
+	"string":  "json.TypeString",
+	"number":  "json.TypeNumber",
+	"boolean": "json.TypeBool",
+	"object":  "json.TypeObject",
+	"array":   "json.TypeArray",
+	"null":    "json.TypeNull",
+}
+
+// checks are asserts, translated from test scripts
+type checks struct {
+	status       int
+	timeout      int
+	body         []string
+	headers      []string
+	untranslated []string
+	imports      map[string]bool
+}
+
+// translateScript translates common pm.test checks to asserts, other lines are reported as untranslated
+func translateScript(lines []string) *checks {
+	c := &checks{imports: make(map[string]bool)}
+	aliases := make(map[string]bool)
+
+	for _, line := range statements(lines) {
+		if skipLine.MatchString(line) || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		if m := jsonAlias.FindStringSubmatch(line); m != nil {
+			aliases[m[1]] = true
+
+			continue
+		}
+
+		if !c.translate(line, aliases) {
+			c.untranslated = append(c.untranslated, line)
+		}
+	}
+
+	return c
+}
+
+// statements splits lines to statements without semicolons
+func statements(lines []string) []string {
+	res := make([]string, 0, len(lines))
+
+	for _, line := range lines {
+		for _, s := range strings.Split(line, ";") {
+			if s = strings.TrimSpace(s); s != "" {
+				res = append(res, s)
+			}
+		}
+	}
+
+	return res
+}
+
+func (c *checks) translate(line string, aliases map[string]bool) bool {
+	if m := statusLine.FindStringSubmatch(line); m != nil {
+		return c.setStatus(m[1])
+	}
+
+	if m := statusExpect.FindStringSubmatch(line); m != nil {
+		return c.setStatus(m[1])
+	}
+
+	if statusOK.MatchString(line) {
+		return c.setStatus("200")
+	}
+
+	if m := responseTime.FindStringSubmatch(line); m != nil {
+		c.timeout, _ = strconv.Atoi(m[1])
+
+		return true
+	}
+
+	if m := headerPresent.FindStringSubmatch(line); m != nil {
+		name, ok := jsString(m[1])
+		if !ok {
+			return false
+		}
+
+		c.headers = append(c.headers, fmt.Sprintf("headers.Present(%v)", strconv.Quote(name)))
+		c.imports["github.com/ozontech/cute/asserts/headers"] = true
+
+		return true
+	}
+
+	if m := textInclude.FindStringSubmatch(line); m != nil {
+		value, ok := jsString(m[1])
+		if !ok {
+			return false
+		}
+
+		c.addBody("text", fmt.Sprintf("text.Contains(%v)", strconv.Quote(value)))
+
+		return true
+	}
+
+	if m := jsonBodyPath.FindStringSubmatch(line); m != nil {
+		path, ok := jsString(m[1])
+		if !ok {
+			return false
+		}
+
+		c.addBody("json", fmt.Sprintf("json.Present(%v)", strconv.Quote("$."+path)))
+
+		return true
+	}
+
+	if m := expectLine.FindStringSubmatch(line); m != nil {
+		path, ok := jsonPath(m[1], aliases)
+		if !ok {
+			return false
+		}
+
+		assert, ok := jsonAssert(path, m[2])
+		if !ok {
+			return false
+		}
+
+		c.addBody("json", assert)
+
+		return true
+	}
+
+	return false
+}
+
+func (c *checks) setStatus(code string) bool {
+	c.status, _ = strconv.Atoi(code)
+
+	return true
+}
+
+func (c *checks) addBody(pkg, assert string) {
+	c.body = append(c.body, assert)
+	c.imports["github.com/ozontech/cute/asserts/"+pkg] = true
+}
+
+// jsonPath converts js expression, like jsonData.items[0].id, to JSONPath
+func jsonPath(expr string, aliases map[string]bool) (string, bool) {
+	var rest string
+
+	switch {
+	case strings.HasPrefix(expr, "pm.response.json()"):
+		rest = strings.TrimPrefix(expr, "pm.response.json()")
+	default:
+		end := strings.IndexAny(expr, ".[")
+		if end < 0 {
+			end = len(expr)
+		}
+
+		if !aliases[expr[:end]] {
+			return "", false
+		}
+
+		rest = expr[end:]
+	}
+
+	if !jsPath.MatchString(rest) {
+		return "", false
+	}
+
+	// JSONPath uses single quotes in brackets
+	rest = strings.NewReplacer(`["`, `['`, `"]`, `']`).Replace(rest)
+
+	return "$" + rest, true
+}
+
+// jsonAssert converts chai chain, like to.eql(1), to assert of asserts/json
+func jsonAssert(path, chain string) (string, bool) {
+	p := strconv.Quote(path)
+
+	switch chain {
+	case "to.exist", "to.not.be.undefined", "to.be.not.undefined":
+		return fmt.Sprintf("json.Present(%v)", p), true
+	case "to.not.exist", "to.be.undefined":
+		return fmt.Sprintf("json.NotPresent(%v)", p), true
+	case "to.not.be.empty", "to.be.not.empty":
+		return fmt.Sprintf("json.NotEmpty(%v)", p), true
+	case "to.be.true":
+		return fmt.Sprintf("json.Equal(%v, true)", p), true
+	case "to.be.false":
+		return fmt.Sprintf("json.Equal(%v, false)", p), true
+	case "to.be.null":
+		return fmt.Sprintf("json.Equal(%v, nil)", p), true
+	}
+
+	m := chainWithArg.FindStringSubmatch(chain)
+	if m == nil {
+		return "", false
+	}
+
+	method, arg := m[1], strings.TrimSpace(m[2])
+
+	switch method {
+	case "to.eql", "to.equal", "to.be.eql", "to.be.equal", "to.deep.equal", "to.deep.eql":
+		return equalAssert("Equal", p, arg)
+	case "to.not.eql", "to.not.equal", "to.not.deep.equal":
+		return equalAssert("NotEqual", p, arg)
+	case "to.be.a", "to.be.an":
+		name, ok := jsString(arg)
+		if !ok || jsonTypes[name] == "" {
+			return "", false
+		}
+
+		return fmt.Sprintf("json.IsType(%v, %v)", p, jsonTypes[name]), true
+	case "to.have.lengthOf", "to.have.length":
+		if _, err := strconv.Atoi(arg); err != nil {
+			return "", false
+		}
+
+		return fmt.Sprintf("json.Length(%v, %v)", p, arg), true
+	case "to.include", "to.contain":
+		value, ok := goValue(arg)
+		if !ok {
+			return "", false
+		}
+
+		return fmt.Sprintf("json.Contains(%v, %v)", p, value), true
+	case "to.be.above", "to.be.greaterThan":
+		if _, err := strconv.ParseFloat(arg, 64); err != nil {
+			return "", false
+		}
+
+		return fmt.Sprintf("json.GreaterThan(%v, %v)", p, arg), true
+	case "to.be.below", "to.be.lessThan":
+		if _, err := strconv.ParseFloat(arg, 64); err != nil {
+			return "", false
+		}
+
+		return fmt.Sprintf("json.LessThan(%v, %v)", p, arg), true
+	case "to.match":
+		if !strings.HasPrefix(arg, "/") || !strings.HasSuffix(arg, "/") || len(arg) < 2 {
+			return "", false
+		}
+
+		return fmt.Sprintf("json.Matches(%v, %v)", p, codegen.GoRawString(arg[1:len(arg)-1])), true
+	case "to.be.oneOf":
+		values, ok := jsonLiteral(arg)
+		if !ok || !strings.HasPrefix(values, "[") {
+			return "", false
+		}
+
+		var list []interface{}
+		if json.Unmarshal([]byte(values), &list) != nil {
+			return "", false
+		}
+
+		items := make([]string, 0, len(list))
+
+		for _, item := range list {
+			data, err := json.Marshal(item)
+			if err != nil {
+				return "", false
+			}
+
+			value, isValue := goValue(string(data))
+			if !isValue {
+				return "", false
+			}
+
+			items = append(items, value)
+		}
+
+		return fmt.Sprintf("json.OneOf(%v, %v)", p, strings.Join(items, ", ")), true
+	}
+
+	return "", false
+}
+
+func equalAssert(name, path, arg string) (string, bool) {
+	if value, ok := goValue(arg); ok {
+		return fmt.Sprintf("json.%v(%v, %v)", name, path, value), true
+	}
+
+	if literal, ok := jsonLiteral(arg); ok {
+		return fmt.Sprintf("json.%vJSON(%v, []byte(%v))", name, path, codegen.GoRawString(literal)), true
+	}
+
+	return "", false
+}
+
+// goValue converts js literal or variable getter to Go literal
+func goValue(arg string) (string, bool) {
+	switch arg {
+	case "true", "false":
+		return arg, true
+	case "null":
+		return "nil", true
+	}
+
+	if _, err := strconv.ParseFloat(arg, 64); err == nil {
+		return arg, true
+	}
+
+	if s, ok := jsString(arg); ok {
+		return codegen.GoString(s), true
+	}
+
+	if m := variableGetter.FindStringSubmatch(arg); m != nil {
+		return fmt.Sprintf("expand(%v)", strconv.Quote("{{"+m[2]+"}}")), true
+	}
+
+	return "", false
+}
+
+// jsString returns content of js string literal
+func jsString(arg string) (string, bool) {
+	if len(arg) < 2 {
+		return "", false
+	}
+
+	quote := arg[0]
+	if (quote != '\'' && quote != '"') || arg[len(arg)-1] != quote {
+		return "", false
+	}
+
+	content := arg[1 : len(arg)-1]
+	if strings.ContainsRune(strings.ReplaceAll(content, `\`+string(quote), ""), rune(quote)) {
+		return "", false
+	}
+
+	return strings.ReplaceAll(content, `\`+string(quote), string(quote)), true
+}
+
+// jsonLiteral converts js object or array literal to JSON
+func jsonLiteral(arg string) (string, bool) {
+	if !strings.HasPrefix(arg, "{") && !strings.HasPrefix(arg, "[") {
+		return "", false
+	}
+
+	literal := bareKey.ReplaceAllString(singleToDoubleQuotes(arg), `$1"$2":`)
+
+	if !json.Valid([]byte(literal)) {
+		return "", false
+	}
+
+	return literal, true
+}
+
+// singleToDoubleQuotes replaces single quoted strings with double quoted strings
+func singleToDoubleQuotes(s string) string {
+	var (
+		b        strings.Builder
+		inSingle bool
+		inDouble bool
+	)
+
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+
+		switch {
+		case ch == '\\' && i+1 < len(s):
+			if inSingle && s[i+1] == '\'' {
+				b.WriteByte('\'')
+			} else {
+				b.WriteByte(ch)
+				b.WriteByte(s[i+1])
+			}
+
+			i++
+		case ch == '\'' && !inDouble:
+			inSingle = !inSingle
+
+			b.WriteByte('"')
+		case ch == '"' && inSingle:
+			b.WriteString(`\"`)
+		case ch == '"':
+			inDouble = !inDouble
+
+			b.WriteByte(ch)
+		default:
+			b.WriteByte(ch)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslateScript(t *testing.T) {
+	c := translateScript([]string{
+		`pm.test("Status code is 201", function () {`,
+		`    pm.response.to.have.status(201);`,
+		`});`,
+		`pm.test("Response time", () => {`,
+		`    pm.expect(pm.response.responseTime).to.be.below(500);`,
+		`});`,
+		`pm.test("Body", function () {`,
+		`    var jsonData = pm.response.json();`,
+		`    pm.expect(jsonData.name).to.eql("cute");`,
+		`    pm.expect(jsonData.items[0]["id"]).to.be.a('number');`,
+		`    pm.expect(jsonData.items).to.have.lengthOf(2);`,
+		`    pm.expect(jsonData.meta).to.eql({total: 2, 'next': null});`,
+		`    pm.expect(jsonData.token).to.eql(pm.environment.get("token"));`,
+		`    pm.expect(jsonData.role).to.be.oneOf(["admin", "user"]);`,
+		`    pm.expect(jsonData.deleted).to.be.false;`,
+		`});`,
+		`pm.response.to.have.header("Content-Type");`,
+		`pm.expect(pm.response.text()).to.include("cute");`,
+		`pm.environment.set("id", pm.response.json().id);`,
+	})
+
+	require.Equal(t, 201, c.status)
+	require.Equal(t, 500, c.timeout)
+	require.Equal(t, []string{
+		`json.Equal("$.name", "cute")`,
+		`json.IsType("$.items[0]['id']", json.TypeNumber)`,
+		`json.Length("$.items", 2)`,
+		"json.EqualJSON(\"$.meta\", []byte(`{\"total\": 2, \"next\": null}`))",
+		`json.Equal("$.token", expand("{{token}}"))`,
+		`json.OneOf("$.role", "admin", "user")`,
+		`json.Equal("$.deleted", false)`,
+		`text.Contains("cute")`,
+	}, c.body)
+	require.Equal(t, []string{`headers.Present("Content-Type")`}, c.headers)
+	require.Equal(t, []string{`pm.environment.set("id", pm.response.json().id)`}, c.untranslated)
+}
+
+func TestTranslateScriptUnknownAlias(t *testing.T) {
+	c := translateScript([]string{`pm.expect(data.id).to.eql(1);`})
+
+	require.Empty(t, c.body)
+	require.Equal(t, []string{`pm.expect(data.id).to.eql(1)`}, c.untranslated)
+}
@@ -0,0 +1,238 @@
+package cute
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ozontech/cute/internal/openapi"
+)
+
+// coveragePathParam is a parameter of templated path, for example {id}
+var coveragePathParam = regexp.MustCompile(`\{[^{}]+\}`)
+
+// Coverage records operations of OpenAPI document, which are called by tests
+// Use WithCoverage for record requests of HTTPTestMaker and Coverage.WriteReport for write report after tests.
+// Example:
+//
+//	func TestMain(m *testing.M) {
+//		code := m.Run()
+//
+//		if err := coverage.WriteReport("coverage"); err != nil {
+//			log.Println(err)
+//		}
+//
+//		os.Exit(code)
+//	}
+type Coverage struct {
+	spec       string
+	operations []*coverageOperation
+	basePaths  []string
+
+	mu           sync.Mutex
+	undocumented map[string]int
+}
+
+type coverageOperation struct {
+	method      string
+	path        string
+	operationID string
+	pattern     *regexp.Regexp
+	// literals is a count of path segments without parameters, more specific paths are matched first
+	literals int
+
+	statuses   []string
+	parameters []coverageParameter
+
+	calls    int
+	observed map[int]bool
+	sent     map[string]bool
+}
+
+type coverageParameter struct {
+	name string
+	in   string
+}
+
+func (p coverageParameter) key() string {
+	return p.in + ":" + p.name
+}
+
+// NewCoverage is a function for create coverage of OpenAPI 3 document in JSON or YAML
+func NewCoverage(spec string) (*Coverage, error) {
+	doc, err := openapi.Load(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	ops, err := doc.Operations()
+	if err != nil {
+		return nil, fmt.Errorf("could not read operations error: '%s'", err)
+	}
+
+	c := &Coverage{
+		spec:         spec,
+		operations:   make([]*coverageOperation, 0, len(ops)),
+		basePaths:    make([]string, 0, len(doc.Servers)),
+		undocumented: make(map[string]int),
+	}
+
+	for _, server := range doc.Servers {
+		if u, err := url.Parse(server.URL); err == nil && strings.Trim(u.Path, "/") != "" {
+			c.basePaths = append(c.basePaths, "/"+strings.Trim(u.Path, "/"))
+		}
+	}
+
+	for _, op := range ops {
+		pattern, literals := pathPattern(op.Path)
+
+		operation := &coverageOperation{
+			method:      op.Method,
+			path:        op.Path,
+			operationID: op.OperationID,
+			pattern:     pattern,
+			literals:    literals,
+			observed:    make(map[int]bool),
+			sent:        make(map[string]bool),
+		}
+
+		for status := range op.Responses {
+			if status != "default" {
+				operation.statuses = append(operation.statuses, strings.ToUpper(status))
+			}
+		}
+
+		sort.Strings(operation.statuses)
+
+		for _, param := range op.Parameters {
+			// Path parameters are always sent
+			if param.In == "path" {
+				continue
+			}
+
+			operation.parameters = append(operation.parameters, coverageParameter{name: param.Name, in: param.In})
+		}
+
+		c.operations = append(c.operations, operation)
+	}
+
+	// More specific paths, like /users/me, are matched before /users/{id}
+	sort.SliceStable(c.operations, func(i, j int) bool {
+		return c.operations[i].literals > c.operations[j].literals
+	})
+
+	return c, nil
+}
+
+// pathPattern returns regexp for templated path and count of segments without parameters
+// It's called once for every operation, when document is loaded, so record only matches compiled patterns.
+func pathPattern(path string) (*regexp.Regexp, int) {
+	var (
+		segments = strings.Split(strings.Trim(path, "/"), "/")
+		literals = 0
+	)
+
+	for i, segment := range segments {
+		if !coveragePathParam.MatchString(segment) {
+			literals++
+		}
+
+		parts := coveragePathParam.Split(segment, -1)
+		for j, part := range parts {
+			parts[j] = regexp.QuoteMeta(part)
+		}
+
+		segments[i] = strings.Join(parts, "[^/]+")
+	}
+
+	return regexp.MustCompile("^/" + strings.Join(segments, "/") + "/?$"), literals
+}
+
+// record saves operation, status code and parameters of request
+func (c *Coverage) record(req *http.Request, status int) {
+	if c == nil || req == nil || req.URL == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	op := c.match(req.Method, req.URL.Path)
+	if op == nil {
+		c.undocumented[req.Method+" "+req.URL.Path]++
+
+		return
+	}
+
+	op.calls++
+	op.observed[status] = true
+
+	for _, param := range op.parameters {
+		if requestHasParameter(req, param) {
+			op.sent[param.key()] = true
+		}
+	}
+}
+
+func (c *Coverage) match(method, path string) *coverageOperation {
+	paths := []string{path}
+
+	for _, base := range c.basePaths {
+		if strings.HasPrefix(path, base) {
+			paths = append(paths, strings.TrimPrefix(path, base))
+		}
+	}
+
+	for _, op := range c.operations {
+		if !strings.EqualFold(op.method, method) {
+			continue
+		}
+
+		for _, p := range paths {
+			if op.pattern.MatchString(p) {
+				return op
+			}
+		}
+	}
+
+	return nil
+}
+
+func requestHasParameter(req *http.Request, param coverageParameter) bool {
+	switch param.in {
+	case "query":
+		_, ok := req.URL.Query()[param.name]
+
+		return ok
+	case "header":
+		return req.Header.Get(param.name) != ""
+	case "cookie":
+		_, err := req.Cookie(param.name)
+
+		return err == nil
+	}
+
+	return false
+}
+
+// statusCovered returns true, if documented status, like 200 or 4XX, is observed
+func statusCovered(status string, observed map[int]bool) bool {
+	if code, err := strconv.Atoi(status); err == nil {
+		return observed[code]
+	}
+
+	if len(status) == 3 && strings.HasSuffix(status, "XX") {
+		for code := range observed {
+			if strconv.Itoa(code)[0] == status[0] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
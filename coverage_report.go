@@ -0,0 +1,296 @@
+package cute
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Names of files, which are written by Coverage.WriteReport
+const (
+	CoverageJSONFile     = "coverage.json"
+	CoverageMarkdownFile = "coverage.md"
+	CoverageHTMLFile     = "coverage.html"
+)
+
+// CoverageReport is a report of coverage of OpenAPI document
+type CoverageReport struct {
+	Spec string `json:"spec"`
+
+	Operations CoverageTotal `json:"operations"`
+	Statuses   CoverageTotal `json:"statuses"`
+	Parameters CoverageTotal `json:"parameters"`
+
+	Items []CoverageItem `json:"items"`
+	// Undocumented are requests, which don't match any operation of document
+	Undocumented []string `json:"undocumented"`
+}
+
+// CoverageTotal is a count of covered and documented items
+type CoverageTotal struct {
+	Covered int     `json:"covered"`
+	Total   int     `json:"total"`
+	Percent float64 `json:"percent"`
+}
+
+// CoverageItem is a coverage of one operation
+type CoverageItem struct {
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	OperationID string `json:"operation_id,omitempty"`
+	Calls       int    `json:"calls"`
+
+	// ObservedStatuses are all received status codes, documented or not
+	ObservedStatuses []int `json:"observed_statuses"`
+	// MissedStatuses are documented status codes, which were never received
+	MissedStatuses []string `json:"missed_statuses"`
+	// UndocumentedStatuses are received status codes, which are not documented
+	UndocumentedStatuses []int `json:"undocumented_statuses"`
+	// MissedParameters are query, header and cookie parameters, which were never sent, like query:limit
+	MissedParameters []string `json:"missed_parameters"`
+}
+
+func newCoverageTotal(covered, total int) CoverageTotal {
+	res := CoverageTotal{Covered: covered, Total: total, Percent: 100}
+
+	if total != 0 {
+		res.Percent = float64(covered*10000/total) / 100
+	}
+
+	return res
+}
+
+// Report returns report of recorded requests
+func (c *Coverage) Report() CoverageReport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var (
+		res = CoverageReport{
+			Spec:         c.spec,
+			Items:        make([]CoverageItem, 0, len(c.operations)),
+			Undocumented: make([]string, 0, len(c.undocumented)),
+		}
+
+		calledOps, statuses, coveredStatuses, params, sentParams int
+	)
+
+	for _, op := range c.operations {
+		item := CoverageItem{
+			Method:               op.method,
+			Path:                 op.path,
+			OperationID:          op.operationID,
+			Calls:                op.calls,
+			ObservedStatuses:     make([]int, 0, len(op.observed)),
+			MissedStatuses:       make([]string, 0),
+			UndocumentedStatuses: make([]int, 0),
+			MissedParameters:     make([]string, 0),
+		}
+
+		if op.calls != 0 {
+			calledOps++
+		}
+
+		for status := range op.observed {
+			item.ObservedStatuses = append(item.ObservedStatuses, status)
+
+			if !op.documented(status) {
+				item.UndocumentedStatuses = append(item.UndocumentedStatuses, status)
+			}
+		}
+
+		sort.Ints(item.ObservedStatuses)
+		sort.Ints(item.UndocumentedStatuses)
+
+		for _, status := range op.statuses {
+			if statusCovered(status, op.observed) {
+				coveredStatuses++
+			} else {
+				item.MissedStatuses = append(item.MissedStatuses, status)
+			}
+		}
+
+		for _, param := range op.parameters {
+			if op.sent[param.key()] {
+				sentParams++
+			} else {
+				item.MissedParameters = append(item.MissedParameters, param.key())
+			}
+		}
+
+		statuses += len(op.statuses)
+		params += len(op.parameters)
+
+		res.Items = append(res.Items, item)
+	}
+
+	// Items are sorted by path and method, like in document
+	sort.SliceStable(res.Items, func(i, j int) bool {
+		if res.Items[i].Path != res.Items[j].Path {
+			return res.Items[i].Path < res.Items[j].Path
+		}
+
+		return res.Items[i].Method < res.Items[j].Method
+	})
+
+	for request, count := range c.undocumented {
+		res.Undocumented = append(res.Undocumented, fmt.Sprintf("%v (%v)", request, count))
+	}
+
+	sort.Strings(res.Undocumented)
+
+	res.Operations = newCoverageTotal(calledOps, len(c.operations))
+	res.Statuses = newCoverageTotal(coveredStatuses, statuses)
+	res.Parameters = newCoverageTotal(sentParams, params)
+
+	return res
+}
+
+func (op *coverageOperation) documented(status int) bool {
+	for _, documented := range op.statuses {
+		if statusCovered(documented, map[int]bool{status: true}) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WriteReport is a function for write report to directory in JSON, Markdown and HTML
+// Directory is created, if it doesn't exist.
+func (c *Coverage) WriteReport(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("could not create coverage directory error: '%s'", err)
+	}
+
+	report := c.Report()
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not marshal coverage report error: '%s'", err)
+	}
+
+	html, err := report.HTML()
+	if err != nil {
+		return err
+	}
+
+	files := map[string][]byte{
+		CoverageJSONFile:     data,
+		CoverageMarkdownFile: []byte(report.Markdown()),
+		CoverageHTMLFile:     html,
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0o600); err != nil {
+			return fmt.Errorf("could not write coverage report %v error: '%s'", name, err)
+		}
+	}
+
+	return nil
+}
+
+// Markdown returns report in Markdown
+func (r CoverageReport) Markdown() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# API coverage\n\nSpec: `%v`\n\n", r.Spec)
+	b.WriteString("| | Covered | Total | % |\n|---|---|---|---|\n")
+	fmt.Fprintf(&b, "| Operations | %v | %v | %.2f |\n", r.Operations.Covered, r.Operations.Total, r.Operations.Percent)
+	fmt.Fprintf(&b, "| Status codes | %v | %v | %.2f |\n", r.Statuses.Covered, r.Statuses.Total, r.Statuses.Percent)
+	fmt.Fprintf(&b, "| Parameters | %v | %v | %.2f |\n\n", r.Parameters.Covered, r.Parameters.Total, r.Parameters.Percent)
+
+	b.WriteString("## Operations\n\n")
+	b.WriteString("| Method | Path | Calls | Observed statuses | Missed statuses | Undocumented statuses | Missed parameters |\n")
+	b.WriteString("|---|---|---|---|---|---|---|\n")
+
+	for _, item := range r.Items {
+		fmt.Fprintf(&b, "| %v | `%v` | %v | %v | %v | %v | %v |\n",
+			item.Method,
+			item.Path,
+			item.Calls,
+			joinInts(item.ObservedStatuses),
+			strings.Join(item.MissedStatuses, ", "),
+			joinInts(item.UndocumentedStatuses),
+			strings.Join(item.MissedParameters, ", "),
+		)
+	}
+
+	if len(r.Undocumented) != 0 {
+		b.WriteString("\n## Undocumented requests\n\n")
+
+		for _, request := range r.Undocumented {
+			fmt.Fprintf(&b, "- `%v`\n", request)
+		}
+	}
+
+	return b.String()
+}
+
+var coverageHTMLTemplate = template.Must(template.New("coverage").Funcs(template.FuncMap{
+	"join":     strings.Join,
+	"joinInts": joinInts,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>API coverage</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+tr.missed { background: #fdd; }
+</style>
+</head>
+<body>
+<h1>API coverage</h1>
+<p>Spec: <code>{{.Spec}}</code></p>
+<table>
+<tr><th></th><th>Covered</th><th>Total</th><th>%</th></tr>
+<tr><td>Operations</td><td>{{.Operations.Covered}}</td><td>{{.Operations.Total}}</td><td>{{printf "%.2f" .Operations.Percent}}</td></tr>
+<tr><td>Status codes</td><td>{{.Statuses.Covered}}</td><td>{{.Statuses.Total}}</td><td>{{printf "%.2f" .Statuses.Percent}}</td></tr>
+<tr><td>Parameters</td><td>{{.Parameters.Covered}}</td><td>{{.Parameters.Total}}</td><td>{{printf "%.2f" .Parameters.Percent}}</td></tr>
+</table>
+<h2>Operations</h2>
+<table>
+<tr><th>Method</th><th>Path</th><th>Calls</th><th>Observed statuses</th><th>Missed statuses</th><th>Undocumented statuses</th><th>Missed parameters</th></tr>
+{{- range .Items}}
+<tr{{if eq .Calls 0}} class="missed"{{end}}><td>{{.Method}}</td><td><code>{{.Path}}</code></td><td>{{.Calls}}</td><td>{{joinInts .ObservedStatuses}}</td><td>{{join .MissedStatuses ", "}}</td><td>{{joinInts .UndocumentedStatuses}}</td><td>{{join .MissedParameters ", "}}</td></tr>
+{{- end}}
+</table>
+{{- if .Undocumented}}
+<h2>Undocumented requests</h2>
+<ul>
+{{- range .Undocumented}}
+<li><code>{{.}}</code></li>
+{{- end}}
+</ul>
+{{- end}}
+</body>
+</html>
+`))
+
+// HTML returns report in HTML
+func (r CoverageReport) HTML() ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := coverageHTMLTemplate.Execute(&buf, r); err != nil {
+		return nil, fmt.Errorf("could not render coverage report error: '%s'", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func joinInts(values []int) string {
+	res := make([]string, 0, len(values))
+	for _, v := range values {
+		res = append(res, fmt.Sprint(v))
+	}
+
+	return strings.Join(res, ", ")
+}
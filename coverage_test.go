@@ -0,0 +1,212 @@
+package cute
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const coverageSpec = `
+openapi: 3.0.0
+info:
+  title: users
+  version: "1"
+servers:
+  - url: https://go.com/api
+paths:
+  /users:
+    parameters:
+      - name: X-Trace
+        in: header
+    get:
+      operationId: listUsers
+      parameters:
+        - name: limit
+          in: query
+        - name: session
+          in: cookie
+      responses:
+        "200":
+          description: ok
+        4XX:
+          description: bad request
+        default:
+          description: error
+  /users/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+    get:
+      responses:
+        "200":
+          description: ok
+        "404":
+          description: not found
+    delete:
+      responses:
+        "204":
+          description: deleted
+  /users/me:
+    get:
+      responses:
+        "200":
+          description: ok
+`
+
+func newTestCoverage(t *testing.T) *Coverage {
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(coverageSpec), 0600))
+
+	coverage, err := NewCoverage(path)
+	require.NoError(t, err)
+
+	return coverage
+}
+
+func TestCoverageReport(t *testing.T) {
+	coverage := newTestCoverage(t)
+
+	record := func(method, uri string, status int, headers ...string) {
+		req, err := http.NewRequest(method, uri, nil)
+		require.NoError(t, err)
+
+		for _, header := range headers {
+			req.Header.Set(header, "1")
+		}
+
+		coverage.record(req, status)
+	}
+
+	record(http.MethodGet, "https://go.com/api/users?limit=10", http.StatusOK, "X-Trace")
+	record(http.MethodGet, "https://go.com/api/users", http.StatusBadRequest)
+	record(http.MethodGet, "https://go.com/api/users/me", http.StatusOK)
+	record(http.MethodGet, "http://localhost/users/1", http.StatusInternalServerError)
+	record(http.MethodPost, "https://go.com/api/users", http.StatusCreated)
+
+	report := coverage.Report()
+
+	require.Equal(t, CoverageTotal{Covered: 3, Total: 4, Percent: 75}, report.Operations)
+	require.Equal(t, CoverageTotal{Covered: 3, Total: 6, Percent: 50}, report.Statuses)
+	require.Equal(t, CoverageTotal{Covered: 2, Total: 3, Percent: 66.66}, report.Parameters)
+	require.Equal(t, []string{"POST /api/users (1)"}, report.Undocumented)
+
+	require.Equal(t, []CoverageItem{
+		{
+			Method:               http.MethodGet,
+			Path:                 "/users",
+			OperationID:          "listUsers",
+			Calls:                2,
+			ObservedStatuses:     []int{200, 400},
+			MissedStatuses:       []string{},
+			UndocumentedStatuses: []int{},
+			MissedParameters:     []string{"cookie:session"},
+		},
+		{
+			Method:               http.MethodGet,
+			Path:                 "/users/me",
+			Calls:                1,
+			ObservedStatuses:     []int{200},
+			MissedStatuses:       []string{},
+			UndocumentedStatuses: []int{},
+			MissedParameters:     []string{},
+		},
+		{
+			Method:               http.MethodDelete,
+			Path:                 "/users/{id}",
+			ObservedStatuses:     []int{},
+			MissedStatuses:       []string{"204"},
+			UndocumentedStatuses: []int{},
+			MissedParameters:     []string{},
+		},
+		{
+			Method:               http.MethodGet,
+			Path:                 "/users/{id}",
+			Calls:                1,
+			ObservedStatuses:     []int{500},
+			MissedStatuses:       []string{"200", "404"},
+			UndocumentedStatuses: []int{500},
+			MissedParameters:     []string{},
+		},
+	}, report.Items)
+}
+
+func TestCoverageWithTest(t *testing.T) {
+	srv, _ := newFinallyServer(t)
+	coverage := newTestCoverage(t)
+
+	maker := NewHTTPTestMaker(WithCoverage(coverage), WithBaseURL(srv.URL))
+
+	builder := maker.NewTestBuilder().
+		Title("coverage").
+		Create().
+		RequestBuilder(WithURI("/users/1"), WithMethod(http.MethodDelete)).
+		ExpectStatus(http.StatusNoContent)
+
+	// Failed test is recorded too
+	_, failed, _ := executeRecorded(t, builder)
+	require.True(t, failed)
+
+	report := coverage.Report()
+	require.Equal(t, 1, report.Operations.Covered)
+	require.Equal(t, []int{http.StatusOK}, report.Items[2].ObservedStatuses)
+}
+
+func TestCoverageWithTableTest(t *testing.T) {
+	srv, _ := newFinallyServer(t)
+	coverage := newTestCoverage(t)
+
+	maker := NewHTTPTestMaker(WithCoverage(coverage), WithBaseURL(srv.URL))
+
+	maker.NewTestBuilder().
+		Title("coverage").
+		CreateTableTest().
+		PutTests(
+			&Test{
+				Name:    "get user",
+				Request: &Request{Builders: []RequestBuilder{WithURI("/users/1"), WithMethod(http.MethodGet)}},
+				Expect:  &Expect{Code: http.StatusOK},
+			},
+			&Test{
+				Name:    "list users",
+				Request: &Request{Builders: []RequestBuilder{WithURI("/users"), WithMethod(http.MethodGet)}},
+				Expect:  &Expect{Code: http.StatusOK},
+			},
+		).
+		ExecuteTest(context.Background(), t)
+
+	report := coverage.Report()
+	require.Equal(t, 2, report.Operations.Covered)
+}
+
+func TestCoverageWriteReport(t *testing.T) {
+	coverage := newTestCoverage(t)
+	dir := filepath.Join(t.TempDir(), "coverage")
+
+	req, err := http.NewRequest(http.MethodDelete, "https://go.com/api/users/1", nil)
+	require.NoError(t, err)
+
+	coverage.record(req, http.StatusNoContent)
+
+	require.NoError(t, coverage.WriteReport(dir))
+
+	data, err := os.ReadFile(filepath.Join(dir, CoverageJSONFile))
+	require.NoError(t, err)
+
+	var report CoverageReport
+	require.NoError(t, json.Unmarshal(data, &report))
+	require.Equal(t, coverage.Report(), report)
+
+	markdown, err := os.ReadFile(filepath.Join(dir, CoverageMarkdownFile))
+	require.NoError(t, err)
+	require.Contains(t, string(markdown), "| DELETE | `/users/{id}` | 1 | 204 |  |  |  |")
+
+	html, err := os.ReadFile(filepath.Join(dir, CoverageHTMLFile))
+	require.NoError(t, err)
+	require.Contains(t, string(html), `<tr class="missed"><td>GET</td><td><code>/users/me</code></td>`)
+}
@@ -0,0 +1,66 @@
+package cute
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ozontech/cute/internal/curl"
+)
+
+// FromCurl is a function for set method, url, headers, body and forms in request from curl command
+// Supported flags: -X, -H, -d, --data-raw, --data-binary, --data-urlencode, --json, -F, --form-string,
+// -u, -b, -A, -e, -G, -I and --url. Flags, which don't change request, like -s, -k or -L, are ignored.
+// Files of -F name=@path are uploaded as File with Path, files with filename=name are read and uploaded as File with Name and Body.
+// If command could not be parsed, test fails on request creation.
+// Example:
+//
+//	RequestBuilder(
+//		cute.FromCurl(`curl -X POST 'https://example.com/users' -H 'Content-Type: application/json' -d '{"name":"cute"}'`),
+//	)
+func FromCurl(command string) RequestBuilder {
+	cmd, err := curl.Parse(command)
+
+	return func(o *requestOptions) {
+		if err != nil {
+			o.err = fmt.Errorf("could not parse curl command error: '%s'", err)
+
+			return
+		}
+
+		o.method = cmd.Method
+		o.uri = cmd.URL
+		o.url = nil
+
+		for _, header := range cmd.Headers {
+			o.headers[header.Name] = append(o.headers[header.Name], header.Value)
+		}
+
+		if cmd.Body != nil {
+			o.body = cmd.Body
+		}
+
+		for _, form := range cmd.Forms {
+			if form.File == "" {
+				o.forms[form.Name] = []byte(form.Value)
+
+				continue
+			}
+
+			// File with Path is sent with its path as name, so file is read for keep filename of form
+			if form.FileName == "" {
+				o.fileForms[form.Name] = &File{Path: form.File}
+
+				continue
+			}
+
+			body, err := os.ReadFile(form.File)
+			if err != nil {
+				o.err = fmt.Errorf("could not read file of form %v error: '%s'", form.Name, err)
+
+				return
+			}
+
+			o.fileForms[form.Name] = &File{Name: form.FileName, Body: body}
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package cute
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"moul.io/http2curl/v2"
+)
+
+func TestFromCurl(t *testing.T) {
+	ht := &Test{
+		Request: &Request{
+			Builders: []RequestBuilder{
+				FromCurl(`curl -X 'PATCH' -H 'Content-Type: application/json' -H 'X-Id: 1' -d '{"name":"it'\''s"}' 'https://go.com/users?id=1'`),
+				WithQueryKV("lang", "go"),
+			},
+		},
+	}
+
+	req, err := ht.createRequest(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, http.MethodPatch, req.Method)
+	require.Equal(t, "https://go.com/users?id=1&lang=go", req.URL.String())
+	require.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	require.Equal(t, "1", req.Header.Get("X-Id"))
+
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	require.Equal(t, `{"name":"it's"}`, string(body))
+}
+
+func TestFromCurlHTTP2Curl(t *testing.T) {
+	origin, err := http.NewRequest(http.MethodPost, "http://go.com/path?a=1&b=2", bytes.NewReader([]byte("line 1\nline 2 'quoted'")))
+	require.NoError(t, err)
+
+	origin.Header.Set("Authorization", "Bearer token")
+	origin.Header.Set("Content-Type", "text/plain")
+
+	command, err := http2curl.GetCurlCommand(origin)
+	require.NoError(t, err)
+
+	ht := &Test{
+		Request: &Request{
+			Builders: []RequestBuilder{FromCurl(command.String())},
+		},
+	}
+
+	req, err := ht.createRequest(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, origin.Method, req.Method)
+	require.Equal(t, origin.URL.String(), req.URL.String())
+	require.Equal(t, origin.Header, req.Header)
+
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	require.Equal(t, "line 1\nline 2 'quoted'", string(body))
+}
+
+func TestFromCurlForm(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file.txt")
+	require.NoError(t, os.WriteFile(file, []byte("content"), 0600))
+
+	ht := &Test{
+		Request: &Request{
+			Builders: []RequestBuilder{
+				FromCurl(`curl -F 'file=@` + file + `' -F 'name=cute' http://go.com/upload`),
+			},
+		},
+	}
+
+	req, err := ht.createRequest(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, http.MethodPost, req.Method)
+
+	require.NoError(t, req.ParseMultipartForm(1024))
+	require.Equal(t, "cute", req.FormValue("name"))
+
+	f, header, err := req.FormFile("file")
+	require.NoError(t, err)
+	require.Equal(t, "file.txt", header.Filename)
+
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	require.Equal(t, "content", string(content))
+}
+
+func TestFromCurlFormFileNameNotFound(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "not_found")
+
+	ht := &Test{
+		Request: &Request{
+			Builders: []RequestBuilder{
+				FromCurl(`curl -F 'file=@` + file + `;filename=avatar.png' http://go.com/upload`),
+			},
+		},
+	}
+
+	_, err := ht.createRequest(context.Background())
+	require.ErrorContains(t, err, "could not read file of form file error")
+}
+
+func TestFromCurlFormFileName(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file.txt")
+	require.NoError(t, os.WriteFile(file, []byte("content"), 0600))
+
+	ht := &Test{
+		Request: &Request{
+			Builders: []RequestBuilder{
+				FromCurl(`curl -F 'file=@` + file + `;filename=avatar.png' http://go.com/upload`),
+			},
+		},
+	}
+
+	req, err := ht.createRequest(context.Background())
+	require.NoError(t, err)
+
+	require.NoError(t, req.ParseMultipartForm(1024))
+
+	f, header, err := req.FormFile("file")
+	require.NoError(t, err)
+	require.Equal(t, "avatar.png", header.Filename)
+
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	require.Equal(t, "content", string(content))
+}
+
+func TestFromCurlError(t *testing.T) {
+	ht := &Test{
+		Request: &Request{
+			Builders: []RequestBuilder{FromCurl(`curl --unknown http://go.com`)},
+		},
+	}
+
+	_, err := ht.createRequest(context.Background())
+	require.ErrorContains(t, err, "could not parse curl command")
+}
@@ -115,14 +115,46 @@ func createAllureT(t *testing.T) *common.Common {
 
 // executeTests is method for run tests
 // It's could be table tests or usual tests
-func (qt *cute) executeTests(ctx context.Context, allureProvider allureProvider) []ResultsHTTPBuilder {
-	var (
-		res = make([]ResultsHTTPBuilder, 0)
-	)
+func (qt *cute) executeTests(ctx context.Context, allureProvider allureProvider) (res []ResultsHTTPBuilder) {
+	res = make([]ResultsHTTPBuilder, 0)
+
+	if qt.skipNotSelected(allureProvider) {
+		return res
+	}
+
+	if qt.baseProps != nil && qt.baseProps.err != nil {
+		qt.setAllureInformation(allureProvider)
+		allureProvider.Errorf("%v", qt.baseProps.err)
+		allureProvider.BrokenNow()
+
+		return res
+	}
+
+	if qt.baseProps != nil && qt.baseProps.suite != nil {
+		done, err := qt.baseProps.suite.start(ctx, allureProvider)
+		defer done()
+
+		if err != nil {
+			qt.setAllureInformation(allureProvider)
+			allureProvider.Errorf("%v", err)
+			allureProvider.BrokenNow()
+
+			return res
+		}
+	}
+
+	tests, finally := qt.splitFinally()
+
+	// Finally tests are executed, even if test is stopped by FailNow or panic
+	if len(finally) != 0 {
+		defer func() {
+			res = append(res, qt.executeFinally(ctx, allureProvider, finally, recover())...)
+		}()
+	}
 
 	// Cycle for change number of Test
-	for i := 0; i <= qt.countTests; i++ {
-		currentTest := qt.tests[i]
+	for _, currentTest := range tests {
+		currentTest := currentTest
 
 		// Execute by new T for table tests
 		if qt.isTableTest {
@@ -131,6 +163,11 @@ func (qt *cute) executeTests(ctx context.Context, allureProvider allureProvider)
 			allureProvider.Run(tableTestName, func(inT provider.T) {
 				// Set current test name
 				inT.Title(tableTestName)
+				qt.setProfileAllure(inT)
+
+				if qt.baseProps != nil && qt.baseProps.suite != nil {
+					qt.baseProps.suite.addChild(inT)
+				}
 
 				res = append(res, qt.executeInsideAllure(ctx, inT, currentTest))
 			})
@@ -147,6 +184,31 @@ func (qt *cute) executeTests(ctx context.Context, allureProvider allureProvider)
 	return res
 }
 
+// skipNotSelected skips test, if it's not selected by tags, severity or layer
+// Returns true, if test is skipped.
+func (qt *cute) skipNotSelected(allureProvider allureProvider) bool {
+	if qt.baseProps == nil {
+		return false
+	}
+
+	reason, err := qt.baseProps.selection.skipReason(qt.allureLabels)
+	if err != nil {
+		allureProvider.Errorf("%v", err)
+		allureProvider.FailNow()
+
+		return true
+	}
+
+	if reason == "" {
+		return false
+	}
+
+	qt.setAllureInformation(allureProvider)
+	allureProvider.Skipf("%v", reason)
+
+	return true
+}
+
 // executeInsideAllure is method for run test inside allure
 // It's could be table tests or usual tests
 func (qt *cute) executeInsideAllure(ctx context.Context, allureProvider allureProvider, currentTest *Test) ResultsHTTPBuilder {
@@ -159,14 +221,39 @@ func (qt *cute) executeInsideAllure(ctx context.Context, allureProvider allurePr
 }
 
 // executeTestsInsideStep is method for run group of tests inside provider.StepCtx
-func (qt *cute) executeTestsInsideStep(ctx context.Context, stepCtx provider.StepCtx) []ResultsHTTPBuilder {
-	var (
-		res = make([]ResultsHTTPBuilder, 0)
-	)
+func (qt *cute) executeTestsInsideStep(ctx context.Context, stepCtx provider.StepCtx) (res []ResultsHTTPBuilder) {
+	res = make([]ResultsHTTPBuilder, 0)
+
+	if qt.baseProps != nil && qt.baseProps.err != nil {
+		stepCtx.Errorf("%v", qt.baseProps.err)
+		stepCtx.BrokenNow()
+
+		return res
+	}
+
+	if qt.baseProps != nil && qt.baseProps.suite != nil {
+		done, err := qt.baseProps.suite.start(ctx, stepCtx)
+		defer done()
+
+		if err != nil {
+			stepCtx.Errorf("%v", err)
+			stepCtx.BrokenNow()
+
+			return res
+		}
+	}
+
+	tests, finally := qt.splitFinally()
+
+	// Finally tests are executed, even if test is stopped by FailNow or panic
+	if len(finally) != 0 {
+		defer func() {
+			res = append(res, qt.executeFinally(ctx, stepCtx, finally, recover())...)
+		}()
+	}
 
 	// Cycle for change number of Test
-	for i := 0; i <= qt.countTests; i++ {
-		currentTest := qt.tests[i]
+	for _, currentTest := range tests {
 
 		result := currentTest.executeInsideStep(ctx, stepCtx)
 
@@ -0,0 +1,139 @@
+package cute
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+)
+
+const finallyStepName = "Cleanup"
+
+// finallyT is a provider for finally steps
+// Errors of finally steps don't fail or stop test, they are collected and reported as broken.
+type finallyT struct {
+	internalT
+
+	failed bool
+}
+
+func (t *finallyT) Fail() {
+	t.failed = true
+}
+
+func (t *finallyT) FailNow() {
+	t.failed = true
+}
+
+func (t *finallyT) Broken() {
+	t.failed = true
+}
+
+func (t *finallyT) BrokenNow() {
+	t.failed = true
+}
+
+func (t *finallyT) Error(args ...interface{}) {
+	t.failed = true
+
+	t.internalT.Log(args...)
+}
+
+func (t *finallyT) Errorf(format string, args ...interface{}) {
+	t.failed = true
+
+	t.internalT.Logf(format, args...)
+}
+
+// splitFinally returns usual tests and finally tests
+func (qt *cute) splitFinally() ([]*Test, []*Test) {
+	var (
+		tests   = make([]*Test, 0, len(qt.tests))
+		finally = make([]*Test, 0)
+	)
+
+	for _, test := range qt.tests {
+		if test.finally {
+			finally = append(finally, test)
+
+			continue
+		}
+
+		tests = append(tests, test)
+	}
+
+	return tests, finally
+}
+
+// executeFinally runs finally tests in Cleanup step, even if test was failed, stopped or panicked
+// Failed finally tests are marked as broken. Test is marked as broken only if it wasn't failed,
+// so original failure is not masked. Panic is raised again after cleanup.
+func (qt *cute) executeFinally(ctx context.Context, t internalT, finally []*Test, recovered interface{}) []ResultsHTTPBuilder {
+	var (
+		res    = make([]ResultsHTTPBuilder, 0, len(finally))
+		broken = make([]string, 0)
+	)
+
+	// Cleanup is executed, even if context of test is canceled by timeout
+	ctx = context.WithoutCancel(ctx)
+
+	t.WithNewStep(finallyStepName, func(groupCtx provider.StepCtx) {
+		for i, test := range finally {
+			name := test.AllureStep.Name
+			if name == "" {
+				name = fmt.Sprintf("%v %v", finallyStepName, i+1)
+			}
+
+			groupCtx.WithNewStep(name, func(stepCtx provider.StepCtx) {
+				result, failed := runFinallyTest(ctx, stepCtx, test)
+				if failed {
+					broken = append(broken, name)
+
+					stepCtx.CurrentStep().Broken()
+				}
+
+				if result != nil {
+					res = append(res, result)
+				}
+			})
+
+			test.clearFields()
+		}
+
+		if len(broken) != 0 {
+			groupCtx.CurrentStep().Broken()
+		}
+	})
+
+	if len(broken) != 0 {
+		t.Logf("[ERROR] finally steps are broken: %v", broken)
+
+		if failed, ok := t.(interface{ Failed() bool }); !ok || !failed.Failed() {
+			t.Broken()
+		}
+	}
+
+	if recovered != nil {
+		panic(recovered)
+	}
+
+	return res
+}
+
+func runFinallyTest(ctx context.Context, stepCtx provider.StepCtx, test *Test) (result ResultsHTTPBuilder, failed bool) {
+	ft := &finallyT{internalT: stepCtx}
+
+	defer func() {
+		if r := recover(); r != nil {
+			ft.Logf("[ERROR] finally step panicked: %v", r)
+
+			failed = true
+		}
+	}()
+
+	test.initEmptyFields()
+
+	result = test.startRepeatableTest(ctx, ft)
+
+	return result, ft.failed || result.GetResultState() != ResultStateSuccess
+}
@@ -0,0 +1,207 @@
+package cute
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/core/allure_manager/manager"
+	"github.com/ozontech/allure-go/pkg/framework/core/common"
+	"github.com/stretchr/testify/require"
+)
+
+// recordT is testing.T, which records failures instead of fail test
+type recordT struct {
+	testing.TB
+
+	mu     sync.Mutex
+	failed bool
+}
+
+func (r *recordT) Fail() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.failed = true
+}
+
+func (r *recordT) FailNow() {
+	r.Fail()
+	runtime.Goexit()
+}
+
+func (r *recordT) Failed() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.failed
+}
+
+func (r *recordT) Error(args ...interface{}) {
+	r.Fail()
+	r.TB.Log(args...)
+}
+
+func (r *recordT) Errorf(format string, args ...interface{}) {
+	r.Fail()
+	r.TB.Logf(format, args...)
+}
+
+func (r *recordT) Parallel() {}
+
+func (r *recordT) Run(string, func(t *testing.T)) bool {
+	return true
+}
+
+// executeRecorded runs test with recordT and returns allure result, failed flag and panic
+func executeRecorded(t *testing.T, builder ControlTest) (*allure.Result, bool, interface{}) {
+	var (
+		rt        = &recordT{TB: t}
+		newT      = common.NewT(rt)
+		cfg       = manager.NewProviderConfig().WithFullName(t.Name()).WithPackageName("package").WithSuiteName(t.Name())
+		p         = manager.NewProvider(cfg)
+		recovered interface{}
+		done      = make(chan struct{})
+	)
+
+	p.NewTest(t.Name(), "package")
+	newT.SetProvider(p)
+	newT.Provider.TestContext()
+
+	go func() {
+		defer close(done)
+		defer func() {
+			recovered = recover()
+		}()
+
+		builder.ExecuteTest(context.Background(), newT)
+	}()
+
+	<-done
+
+	return p.GetResult(), rt.Failed(), recovered
+}
+
+func newFinallyServer(t *testing.T) (*httptest.Server, *[]string) {
+	var (
+		mu      sync.Mutex
+		methods = make([]string, 0)
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		methods = append(methods, r.Method)
+		mu.Unlock()
+
+		if r.URL.Path == "/error" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &methods
+}
+
+func TestFinallyAfterBrokenStep(t *testing.T) {
+	srv, methods := newFinallyServer(t)
+
+	builder := NewHTTPTestMaker().NewTestBuilder().
+		Title("finally").
+		CreateStep("Create").
+		RequestBuilder(WithURI(srv.URL), WithMethod(http.MethodPost)).
+		BrokenAssertBody(func(_ []byte) error {
+			return errors.New("broken")
+		}).
+		NextTest().
+		CreateStep("Get").
+		RequestBuilder(WithURI(srv.URL), WithMethod(http.MethodGet)).
+		Finally("Delete").
+		RequestBuilder(WithURI(srv.URL), WithMethod(http.MethodDelete)).
+		ExpectStatus(http.StatusOK)
+
+	result, failed, recovered := executeRecorded(t, builder)
+
+	require.Nil(t, recovered)
+	require.True(t, failed)
+	require.Equal(t, []string{http.MethodPost, http.MethodDelete}, *methods)
+	require.Equal(t, allure.Broken, result.Status)
+
+	cleanup := result.Steps[len(result.Steps)-1]
+	require.Equal(t, "Cleanup", cleanup.Name)
+	require.Equal(t, allure.Passed, cleanup.Status)
+	require.Equal(t, "Delete", cleanup.Steps[0].Name)
+}
+
+func TestFinallyBrokenDoesNotMaskFailure(t *testing.T) {
+	srv, methods := newFinallyServer(t)
+
+	builder := NewHTTPTestMaker().NewTestBuilder().
+		Title("finally").
+		Create().
+		RequestBuilder(WithURI(srv.URL), WithMethod(http.MethodPost)).
+		ExpectStatus(http.StatusCreated).
+		Finally("Delete").
+		RequestBuilder(WithURI(srv.URL+"/error"), WithMethod(http.MethodDelete)).
+		ExpectStatus(http.StatusOK)
+
+	result, failed, _ := executeRecorded(t, builder)
+
+	require.True(t, failed)
+	require.Equal(t, []string{http.MethodPost, http.MethodDelete}, *methods)
+	require.Equal(t, allure.Failed, result.Status)
+
+	cleanup := result.Steps[len(result.Steps)-1]
+	require.Equal(t, allure.Broken, cleanup.Status)
+	require.Equal(t, allure.Broken, cleanup.Steps[0].Status)
+}
+
+func TestFinallyBrokenAfterSuccess(t *testing.T) {
+	srv, _ := newFinallyServer(t)
+
+	builder := NewHTTPTestMaker().NewTestBuilder().
+		Title("finally").
+		Create().
+		RequestBuilder(WithURI(srv.URL), WithMethod(http.MethodPost)).
+		ExpectStatus(http.StatusOK).
+		Finally("Delete").
+		RequestBuilder(WithURI(srv.URL+"/error"), WithMethod(http.MethodDelete)).
+		ExpectStatus(http.StatusOK)
+
+	result, failed, _ := executeRecorded(t, builder)
+
+	require.True(t, failed)
+	require.Equal(t, allure.Broken, result.Status)
+}
+
+func TestFinallyAfterPanic(t *testing.T) {
+	srv, methods := newFinallyServer(t)
+
+	builder := NewHTTPTestMaker().NewTestBuilder().
+		Title("finally").
+		Create().
+		BeforeExecute(func(_ *http.Request) error {
+			panic("before panicked")
+		}).
+		RequestBuilder(WithURI(srv.URL), WithMethod(http.MethodPost)).
+		Finally("Delete").
+		RequestBuilder(WithURI(srv.URL), WithMethod(http.MethodDelete))
+
+	_, failed, recovered := executeRecorded(t, builder)
+
+	require.Nil(t, recovered)
+	require.True(t, failed)
+	require.Equal(t, []string{http.MethodDelete}, *methods)
+}
+
+func TestFinallyKeptAfterClearFields(t *testing.T) {
+	ht := &Test{finally: true}
+
+	ht.clearFields()
+
+	require.True(t, ht.finally)
+}
@@ -0,0 +1,181 @@
+package cute
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	cuteErrors "github.com/ozontech/cute/errors"
+	"github.com/ozontech/cute/internal/jsondiff"
+)
+
+// IdempotencyKeyHeader is a default header of idempotency key
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+const defaultIdempotencyCount = 2
+
+// Idempotency is a configuration of idempotency check, see RequestIdempotency
+// The same request with the same key is sent Count times, sequentially with Delay or concurrently.
+// Status codes of responses have to follow Statuses, JSON bodies of successful (2xx) responses
+// have to be the same as body of the first successful response, values by IgnorePaths are not compared.
+type Idempotency struct {
+	// Header is a name of header with key, default is Idempotency-Key
+	Header string
+	// Key is a value of header, if it's empty, value of request header or random UUID is used
+	Key string
+	// Count is a count of requests, default is 2
+	Count int
+	// Delay is a delay between sequential requests
+	Delay time.Duration
+	// Concurrent sends all requests at the same time, like RequestRace
+	Concurrent bool
+
+	// Statuses is an expected pattern of status codes, the last status is expected for the rest responses.
+	// For example, {201, 200} means that the first response is 201 and the rest are 200.
+	// If requests are concurrent, order of responses is not checked, only count of statuses.
+	// If it's empty, statuses are not checked.
+	Statuses []int
+	// IgnorePaths are jsonpath expressions of values, which could differ, for example $.request_id
+	IgnorePaths []string
+}
+
+func (i *Idempotency) count() int {
+	if i.Count == 0 {
+		return defaultIdempotencyCount
+	}
+
+	return i.Count
+}
+
+// setKey sets idempotency key to request, if request has no key
+func (i *Idempotency) setKey(req *http.Request) {
+	header := i.Header
+	if header == "" {
+		header = IdempotencyKeyHeader
+	}
+
+	switch {
+	case i.Key != "":
+		req.Header.Set(header, i.Key)
+	case req.Header.Get(header) == "":
+		req.Header.Set(header, uuid.NewString())
+	}
+}
+
+// expectedStatuses returns expected status of every response
+func (i *Idempotency) expectedStatuses(count int) []int {
+	res := make([]int, count)
+
+	for n := range res {
+		res[n] = i.Statuses[len(i.Statuses)-1]
+
+		if n < len(i.Statuses) {
+			res[n] = i.Statuses[n]
+		}
+	}
+
+	return res
+}
+
+func (it *Test) assertIdempotency(t internalT, responses []*http.Response) []error {
+	idempotency := it.Request.Idempotency
+
+	return it.executeWithStep(t, "Assert idempotency", func(t T) []error {
+		errs := make([]error, 0)
+
+		if len(idempotency.Statuses) != 0 {
+			if err := idempotency.checkStatuses(responses); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		return append(errs, it.compareIdempotentBodies(t, responses)...)
+	})
+}
+
+func (i *Idempotency) checkStatuses(responses []*http.Response) error {
+	var (
+		expected = i.expectedStatuses(len(responses))
+		actual   = make([]int, len(responses))
+	)
+
+	for n, resp := range responses {
+		if resp != nil {
+			actual[n] = resp.StatusCode
+		}
+	}
+
+	if i.Concurrent {
+		sort.Ints(expected)
+		sort.Ints(actual)
+	}
+
+	for n := range actual {
+		if actual[n] != expected[n] {
+			return cuteErrors.NewAssertError(
+				"Assert idempotency statuses",
+				fmt.Sprintf("expected statuses %v, but was %v", expected, actual),
+				actual,
+				expected)
+		}
+	}
+
+	return nil
+}
+
+// compareIdempotentBodies compares bodies of successful responses with body of the first successful response
+func (it *Test) compareIdempotentBodies(t T, responses []*http.Response) []error {
+	var (
+		errs     = make([]error, 0)
+		first    []byte
+		hasFirst bool
+		index    int
+	)
+
+	for n, resp := range responses {
+		if resp == nil || resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			continue
+		}
+
+		responseBody, err := it.readResponseBody(resp)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not get body of response %v. error %w", n+1, err))
+
+			continue
+		}
+
+		body := responseBody.plain
+
+		if !hasFirst {
+			first, hasFirst, index = body, true, n
+
+			continue
+		}
+
+		if err = compareBodies(first, body, it.Request.Idempotency.IgnorePaths); err != nil {
+			it.Error(t, "Body of response %v is not the same as body of response %v", n+1, index+1)
+
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+func compareBodies(first, body []byte, ignore []string) error {
+	// Not JSON bodies are compared as is
+	if !json.Valid(first) || !json.Valid(body) {
+		if !bytes.Equal(first, body) {
+			return cuteErrors.NewAssertError("Body", "body is not the same", string(body), string(first))
+		}
+
+		return nil
+	}
+
+	return jsondiff.Compare(first, body, ignore...)
+}
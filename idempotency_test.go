@@ -0,0 +1,108 @@
+package cute
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newIdempotentServer returns server, which creates order once for key and replays response for the same key
+// request_id is different in every response.
+func newIdempotentServer(t *testing.T) (*httptest.Server, *[]string) {
+	var (
+		mu       sync.Mutex
+		keys     = make([]string, 0)
+		orders   = make(map[string]int)
+		requests int
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		key := r.Header.Get(IdempotencyKeyHeader)
+		keys = append(keys, key)
+		requests++
+
+		w.Header().Set("Content-Type", "application/json")
+
+		id, ok := orders[key]
+		if ok {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			id = len(orders) + 1
+			orders[key] = id
+
+			w.WriteHeader(http.StatusCreated)
+		}
+
+		_, _ = fmt.Fprintf(w, `{"id":%v,"request_id":%v}`, id, requests)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &keys
+}
+
+func TestRequestIdempotency(t *testing.T) {
+	for _, concurrent := range []bool{false, true} {
+		srv, keys := newIdempotentServer(t)
+
+		builder := NewHTTPTestMaker().NewTestBuilder().
+			Title("idempotency").
+			Create().
+			RequestIdempotency(Idempotency{
+				Count:       3,
+				Concurrent:  concurrent,
+				Statuses:    []int{http.StatusCreated, http.StatusOK},
+				IgnorePaths: []string{"$.request_id"},
+			}).
+			RequestBuilder(WithURI(srv.URL), WithMethod(http.MethodPost))
+
+		result, failed, _ := executeRecorded(t, builder)
+		require.False(t, failed, concurrent)
+
+		require.Len(t, *keys, 3)
+		require.NotEmpty(t, (*keys)[0])
+		require.Equal(t, (*keys)[0], (*keys)[1])
+		require.Equal(t, (*keys)[0], (*keys)[2])
+
+		require.Len(t, result.Steps, 4)
+		require.Equal(t, fmt.Sprintf("[Replay 1/3] POST %v", srv.URL), result.Steps[0].Name)
+		require.Equal(t, "Assert idempotency", result.Steps[3].Name)
+	}
+}
+
+func TestRequestIdempotencyFailed(t *testing.T) {
+	srv, keys := newIdempotentServer(t)
+
+	builder := NewHTTPTestMaker().NewTestBuilder().
+		Title("idempotency").
+		Create().
+		RequestIdempotency(Idempotency{
+			Key:      "order-1",
+			Statuses: []int{http.StatusCreated},
+		}).
+		RequestBuilder(WithURI(srv.URL), WithMethod(http.MethodPost))
+
+	result, failed, _ := executeRecorded(t, builder)
+	require.True(t, failed)
+	require.Equal(t, []string{"order-1", "order-1"}, *keys)
+
+	step := result.Steps[2]
+	require.Equal(t, "Assert idempotency", step.Name)
+	require.Len(t, step.Steps, 2)
+	require.Equal(t, "Assert idempotency statuses", step.Steps[0].Name)
+	require.Equal(t, "JSON Diff", step.Steps[1].Name)
+	require.NotEmpty(t, step.Steps[1].Attachments)
+}
+
+func TestIdempotencyExpectedStatuses(t *testing.T) {
+	idempotency := &Idempotency{Statuses: []int{http.StatusCreated, http.StatusOK}}
+
+	require.Equal(t, []int{201}, idempotency.expectedStatuses(1))
+	require.Equal(t, []int{201, 200, 200}, idempotency.expectedStatuses(3))
+}
@@ -178,6 +178,7 @@ type RequestHTTPBuilder interface {
 	// WithFileFormKV
 	// WithForm
 	// WithFormKV
+	// WithContentEncoding
 	RequestBuilder(r ...RequestBuilder) ExpectHTTPBuilder
 
 	RequestParams
@@ -219,18 +220,47 @@ type RequestParams interface {
 	RequestRepeatBroken(broken bool) RequestHTTPBuilder
 	RequestRetryBroken(broken bool) RequestHTTPBuilder
 
+	// RequestRace is a function for send the same request count times at the same time.
+	// All requests are started after barrier, every exchange is shown in allure as own step.
+	// Expect (status, asserts, json schema) is applied to every response, AssertRace is applied to all responses.
+	// Race request could not be combined with RequestRetry, RequestIdempotency, RequestPagination or PollUntil.
+	RequestRace(count int) RequestHTTPBuilder
+
+	// RequestIdempotency is a function for replay request with the same idempotency key.
+	// Requests are sent sequentially with delay or concurrently, every exchange is shown in allure as own step.
+	// Status codes have to follow expected pattern, JSON bodies of successful responses have to be the same,
+	// except values by ignored paths.
+	// It could not be combined with RequestRetry, RequestRace, RequestPagination or PollUntil.
+	RequestIdempotency(idempotency Idempotency) RequestHTTPBuilder
+
+	// RequestPagination is a function for follow pages of list request by cursor, Link header or page/offset.
+	// Pages are requested until they are exhausted or Pagination.MaxPages is reached,
+	// every page is shown in allure as nested step of step Pagination.
+	// Expect (status, asserts, json schema) is applied to every page, AssertItems is applied to items of all pages.
+	// Request retry is applied to every page. It could not be combined with RequestRace, RequestIdempotency or PollUntil.
+	RequestPagination(pagination Pagination) RequestHTTPBuilder
+
+	// PollUntil is a function for send request repeatedly with interval and backoff until Poll.Until returns true.
+	// Unlike request retry, non-terminal responses are expected, they are summarized in allure as one attachment.
+	// Expect (status, asserts, json schema) is applied to the terminal response.
+	// Test is failed, if response is not terminal after Poll.Timeout.
+	// It could not be combined with RequestRetry, RequestRace, RequestIdempotency or RequestPagination.
+	PollUntil(poll Poll) RequestHTTPBuilder
+
 	// RequestSanitizerHook sets a RequestSanitizerHook function for the request.
 	// This hook allows you to modify or mask parts of the request URL (e.g., hide sensitive data)
 	// before it is logged or added to the test report (Allure).
 	// Example usage: RequestWithSanitizeHook(func(req *http.Request) { ... }).
 	// Example: RequestWithSanitizeHook(func(req *http.Request) { req.URL.Path = "/masked" }).
 	// Example: RequestWithSanitizeHook(func(req *http.Request) { req.Header["some_header"] = []string{"masked"} }).
+	// Hook gets a copy of request, so real request is not changed.
 	RequestSanitizerHook(hook RequestSanitizerHook) RequestHTTPBuilder
 
 	// ResponseSanitizerHook sets a ResponseSanitizerHook function for the request.
 	// This hook allows you to modify or mask parts of the response body (e.g., hide sensitive data)
 	// before it is logged or added to the test report (Allure).
 	// Example usage: ResponseWithSanitizeHook(func(resp *http.Response) { ... }).
+	// Hook gets a copy of response, so asserts get real response.
 	ResponseSanitizerHook(hook ResponseSanitizerHook) RequestHTTPBuilder
 }
 
@@ -252,6 +282,8 @@ type ExpectHTTPBuilder interface {
 	// "http://www.some_host.com/schema.json"
 	// For get local file use:
 	// "file://./project/me/schema.json"
+	// If maker has JSONSchemaRegistry (see WithJSONSchemaRegistry), path without scheme is resolved from registry:
+	// "users/user.json"
 	ExpectJSONSchemaFile(path string) ExpectHTTPBuilder
 
 	// AssertBody is function for validate response body.
@@ -322,6 +354,15 @@ type ExpectHTTPBuilder interface {
 	// BrokenAssertResponse  is function for validate response, if it's failed, then test will be Broken.
 	// Mark in allure as Broken
 	BrokenAssertResponse(asserts ...AssertResponse) ExpectHTTPBuilder
+	// AssertRace is function for validate all responses of race request, see RequestRace.
+	// For example, exactly one response is 201 and the rest are 409.
+	// Ready-made asserts are in asserts/race.
+	AssertRace(asserts ...AssertRace) ExpectHTTPBuilder
+	// AssertItems is function for validate items of all pages of paginated request, see RequestPagination.
+	// For example, total count of items, uniqueness of ids, ordering across pages.
+	// Ready-made asserts are in asserts/items.
+	AssertItems(asserts ...AssertItems) ExpectHTTPBuilder
+
 	// AssertResponseT is function for validate response with help testing.TB.
 	// You may create allure step inside assert, add attachment, log information, etc.
 	AssertResponseT(asserts ...AssertResponseT) ExpectHTTPBuilder
@@ -342,6 +383,12 @@ type ExpectHTTPBuilder interface {
 type ControlTest interface {
 	NextTest() NextTestBuilder
 
+	// Finally is a function for create cleanup step, which is always executed after other steps,
+	// even if they are failed by require asserts, broken, timed out or panicked.
+	// Finally steps are shown in Cleanup step group in allure.
+	// Errors of finally steps mark step as broken and don't mask original failure of test.
+	Finally(name string) MiddlewareRequest
+
 	// ExecuteTest is a function for execute Test
 	ExecuteTest(ctx context.Context, t tProvider) []ResultsHTTPBuilder
 }
@@ -366,6 +413,21 @@ type ResultsHTTPBuilder interface {
 	GetResultState() ResultState
 }
 
+// ResultsConnection is a scope of methods for information about connection of response
+// Results of cute implement it, so it could be taken from ResultsHTTPBuilder by type assertion:
+//
+//	if conn, ok := result.(cute.ResultsConnection); ok {
+//		protocol := conn.GetProtocol()
+//	}
+type ResultsConnection interface {
+	// GetProtocol is a function, which returns negotiated protocol of response, for example HTTP/2.0
+	// It's empty, if response is not received.
+	GetProtocol() string
+	// GetTLSVersion is a function, which returns negotiated TLS version of response, for example TLS 1.3
+	// It's empty, if connection is not secure or response is not received.
+	GetTLSVersion() string
+}
+
 // BeforeExecute is a function for processing request before test execution
 type BeforeExecute func(*http.Request) error
 
@@ -0,0 +1,115 @@
+// Package codegen contains helpers of commands, which generate Go code of cute tests.
+package codegen
+
+import (
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// defaultPackage is a package of generated file, if it could not be taken from directory
+const defaultPackage = "api"
+
+var statusNames = map[int]string{
+	http.StatusOK:                   "http.StatusOK",
+	http.StatusCreated:              "http.StatusCreated",
+	http.StatusAccepted:             "http.StatusAccepted",
+	http.StatusNonAuthoritativeInfo: "http.StatusNonAuthoritativeInfo",
+	http.StatusNoContent:            "http.StatusNoContent",
+	http.StatusResetContent:         "http.StatusResetContent",
+	http.StatusPartialContent:       "http.StatusPartialContent",
+	http.StatusMultipleChoices:      "http.StatusMultipleChoices",
+	http.StatusMovedPermanently:     "http.StatusMovedPermanently",
+	http.StatusFound:                "http.StatusFound",
+	http.StatusNotModified:          "http.StatusNotModified",
+	http.StatusBadRequest:           "http.StatusBadRequest",
+	http.StatusUnauthorized:         "http.StatusUnauthorized",
+	http.StatusForbidden:            "http.StatusForbidden",
+	http.StatusNotFound:             "http.StatusNotFound",
+	http.StatusConflict:             "http.StatusConflict",
+	http.StatusUnprocessableEntity:  "http.StatusUnprocessableEntity",
+	http.StatusInternalServerError:  "http.StatusInternalServerError",
+}
+
+var methodNames = map[string]string{
+	http.MethodGet:     "http.MethodGet",
+	http.MethodPut:     "http.MethodPut",
+	http.MethodPost:    "http.MethodPost",
+	http.MethodDelete:  "http.MethodDelete",
+	http.MethodOptions: "http.MethodOptions",
+	http.MethodHead:    "http.MethodHead",
+	http.MethodPatch:   "http.MethodPatch",
+	http.MethodTrace:   "http.MethodTrace",
+}
+
+// StatusName returns Go expression of status code, for example http.StatusOK
+// Status without constant is returned as number.
+func StatusName(code int) string {
+	if name, ok := statusNames[code]; ok {
+		return name
+	}
+
+	return strconv.Itoa(code)
+}
+
+// MethodName returns Go expression of http method, for example http.MethodGet
+// Method without constant is returned as string literal.
+func MethodName(method string) string {
+	if name, ok := methodNames[method]; ok {
+		return name
+	}
+
+	return GoString(method)
+}
+
+// GoString returns interpreted string literal
+func GoString(s string) string {
+	return strconv.Quote(s)
+}
+
+// GoRawString returns raw string literal, if it is possible
+func GoRawString(s string) string {
+	if strings.ContainsAny(s, "`\r") {
+		return strconv.Quote(s)
+	}
+
+	return "`" + s + "`"
+}
+
+// PackageName returns package of generated file by name of its directory
+func PackageName(out string) string {
+	if out == "-" {
+		return defaultPackage
+	}
+
+	abs, err := filepath.Abs(out)
+	if err != nil {
+		return defaultPackage
+	}
+
+	name := filepath.Base(filepath.Dir(abs))
+
+	if !IsIdentifier(name) {
+		return defaultPackage
+	}
+
+	return name
+}
+
+// IsIdentifier returns true, if s could be a name of Go package
+func IsIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i, r := range s {
+		if r == '_' || (r >= 'a' && r <= 'z') || (i > 0 && r >= '0' && r <= '9') {
+			continue
+		}
+
+		return false
+	}
+
+	return true
+}
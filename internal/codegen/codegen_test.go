@@ -0,0 +1,30 @@
+package codegen
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNames(t *testing.T) {
+	require.Equal(t, "http.StatusCreated", StatusName(201))
+	require.Equal(t, "418", StatusName(418))
+	require.Equal(t, "http.MethodPatch", MethodName("PATCH"))
+	require.Equal(t, `"PURGE"`, MethodName("PURGE"))
+}
+
+func TestGoString(t *testing.T) {
+	require.Equal(t, `"a\"b"`, GoString(`a"b`))
+	require.Equal(t, "`{\"a\": 1}`", GoRawString(`{"a": 1}`))
+	require.Equal(t, `"a`+"`"+`b"`, GoRawString("a`b"))
+	require.Equal(t, `"a\r\nb"`, GoRawString("a\r\nb"))
+}
+
+func TestPackageName(t *testing.T) {
+	require.Equal(t, "api", PackageName("-"))
+	require.Equal(t, "orders", PackageName(filepath.Join("tests", "orders", "api_test.go")))
+	require.Equal(t, "api", PackageName(filepath.Join("tests", "my-orders", "api_test.go")))
+	require.False(t, IsIdentifier("1api"))
+	require.True(t, IsIdentifier("api_v2"))
+}
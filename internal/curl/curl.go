@@ -0,0 +1,347 @@
+// Package curl parses curl commands, which are copied from terminal, browser or http2curl.
+package curl
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Command is a parsed curl command
+type Command struct {
+	Method  string
+	URL     string
+	Headers []Header
+	Body    []byte
+	Forms   []Form
+}
+
+// Header is a header of request, order of headers is kept
+type Header struct {
+	Name  string
+	Value string
+}
+
+// Form is a field of multipart form
+// If File is not empty, field is a file from disk.
+type Form struct {
+	Name     string
+	Value    string
+	File     string
+	FileName string
+}
+
+// flags without value, which don't change request
+var ignoredFlags = map[string]bool{
+	"-s": true, "--silent": true, "-S": true, "--show-error": true, "-v": true, "--verbose": true,
+	"-k": true, "--insecure": true, "-L": true, "--location": true, "-i": true, "--include": true,
+	"--compressed": true, "-f": true, "--fail": true, "-#": true, "--progress-bar": true,
+	"--http1.1": true, "--http2": true, "--globoff": true, "-g": true,
+}
+
+// flags with value, which don't change request
+var ignoredValueFlags = map[string]bool{
+	"-o": true, "--output": true, "-m": true, "--max-time": true, "--connect-timeout": true,
+	"--retry": true, "-w": true, "--write-out": true, "--cacert": true, "--cert": true, "--key": true,
+}
+
+// flags with value, short flags could be written with value, for example -XPOST
+var valueFlags = map[string]bool{
+	"-X": true, "--request": true, "-H": true, "--header": true, "-d": true, "--data": true,
+	"--data-raw": true, "--data-binary": true, "--data-ascii": true, "--data-urlencode": true,
+	"--json": true, "-F": true, "--form": true, "--form-string": true, "-u": true, "--user": true,
+	"-b": true, "--cookie": true, "-A": true, "--user-agent": true, "-e": true, "--referer": true,
+	"--url": true,
+}
+
+type parser struct {
+	cmd     *Command
+	data    []string
+	get     bool
+	head    bool
+	jsonSet bool
+}
+
+// Parse is a function for parse curl command
+// Files from -d @file and --data-binary @file are read, files of forms are kept as paths.
+func Parse(command string) (*Command, error) {
+	args, err := Split(command)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(args) == 0 || args[0] != "curl" {
+		return nil, fmt.Errorf("command is not a curl command")
+	}
+
+	p := &parser{cmd: &Command{}}
+
+	for i := 1; i < len(args); i++ {
+		arg := args[i]
+
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			if p.cmd.URL != "" {
+				return nil, fmt.Errorf("several urls are not supported: %v and %v", p.cmd.URL, arg)
+			}
+
+			p.cmd.URL = arg
+
+			continue
+		}
+
+		name, value, hasValue := splitFlag(arg)
+
+		switch {
+		case ignoredFlags[name]:
+			continue
+		case ignoredValueFlags[name]:
+			if !hasValue {
+				i++
+			}
+
+			continue
+		case name == "-G" || name == "--get":
+			p.get = true
+
+			continue
+		case name == "-I" || name == "--head":
+			p.head = true
+
+			continue
+		case !valueFlags[name]:
+			return nil, fmt.Errorf("flag %v is not supported", name)
+		}
+
+		if !hasValue {
+			i++
+			if i >= len(args) {
+				return nil, fmt.Errorf("flag %v requires value", name)
+			}
+
+			value = args[i]
+		}
+
+		if err = p.flag(name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = p.finish(); err != nil {
+		return nil, err
+	}
+
+	return p.cmd, nil
+}
+
+// splitFlag splits short flag with value, like -XPOST or -H'Accept: */*'
+func splitFlag(arg string) (string, string, bool) {
+	if strings.HasPrefix(arg, "--") || len(arg) <= 2 {
+		return arg, "", false
+	}
+
+	return arg[:2], arg[2:], true
+}
+
+func (p *parser) flag(name, value string) error {
+	switch name {
+	case "-X", "--request":
+		p.cmd.Method = strings.ToUpper(value)
+	case "--url":
+		p.cmd.URL = value
+	case "-H", "--header":
+		headerName, headerValue, ok := strings.Cut(value, ":")
+		if !ok {
+			return fmt.Errorf("could not parse header %v", value)
+		}
+
+		p.addHeader(strings.TrimSpace(headerName), strings.TrimSpace(headerValue))
+	case "-d", "--data", "--data-ascii":
+		data, err := readData(value, true)
+		if err != nil {
+			return err
+		}
+
+		p.data = append(p.data, data)
+	case "--data-binary":
+		data, err := readData(value, false)
+		if err != nil {
+			return err
+		}
+
+		p.data = append(p.data, data)
+	case "--data-raw":
+		p.data = append(p.data, value)
+	case "--json":
+		data, err := readData(value, false)
+		if err != nil {
+			return err
+		}
+
+		p.data = append(p.data, data)
+		p.jsonSet = true
+	case "--data-urlencode":
+		p.data = append(p.data, urlEncodeData(value))
+	case "-F", "--form":
+		return p.addForm(value, true)
+	case "--form-string":
+		return p.addForm(value, false)
+	case "-u", "--user":
+		p.addHeader("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(value)))
+	case "-b", "--cookie":
+		p.addHeader("Cookie", value)
+	case "-A", "--user-agent":
+		p.addHeader("User-Agent", value)
+	case "-e", "--referer":
+		p.addHeader("Referer", value)
+	}
+
+	return nil
+}
+
+func (p *parser) addHeader(name, value string) {
+	p.cmd.Headers = append(p.cmd.Headers, Header{Name: name, Value: value})
+}
+
+func (p *parser) hasHeader(name string) bool {
+	for _, h := range p.cmd.Headers {
+		if strings.EqualFold(h.Name, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addForm adds form field: name=value, name=@path;filename=name;type=mime or name=<path
+func (p *parser) addForm(value string, special bool) error {
+	name, content, ok := strings.Cut(value, "=")
+	if !ok {
+		return fmt.Errorf("could not parse form %v", value)
+	}
+
+	form := Form{Name: name, Value: content}
+
+	if special && strings.HasPrefix(content, "@") {
+		params := strings.Split(content[1:], ";")
+
+		form.Value = ""
+		form.File = params[0]
+
+		for _, param := range params[1:] {
+			if fileName, isFileName := strings.CutPrefix(param, "filename="); isFileName {
+				form.FileName = strings.Trim(fileName, `"`)
+			}
+		}
+	} else if special && strings.HasPrefix(content, "<") {
+		data, err := os.ReadFile(strings.Split(content[1:], ";")[0])
+		if err != nil {
+			return fmt.Errorf("could not read form %v error: '%s'", name, err)
+		}
+
+		form.Value = string(data)
+	}
+
+	p.cmd.Forms = append(p.cmd.Forms, form)
+
+	return nil
+}
+
+func (p *parser) finish() error {
+	if p.cmd.URL == "" {
+		return fmt.Errorf("url is not found")
+	}
+
+	if !strings.Contains(p.cmd.URL, "://") {
+		p.cmd.URL = "http://" + p.cmd.URL
+	}
+
+	if len(p.data) != 0 && len(p.cmd.Forms) != 0 {
+		return fmt.Errorf("data and forms could not be used together")
+	}
+
+	data := strings.Join(p.data, "&")
+
+	if p.jsonSet {
+		if !p.hasHeader("Content-Type") {
+			p.addHeader("Content-Type", "application/json")
+		}
+
+		if !p.hasHeader("Accept") {
+			p.addHeader("Accept", "application/json")
+		}
+	}
+
+	switch {
+	case p.get && len(p.data) != 0:
+		u, err := url.Parse(p.cmd.URL)
+		if err != nil {
+			return fmt.Errorf("could not parse url %v error: '%s'", p.cmd.URL, err)
+		}
+
+		if u.RawQuery != "" {
+			u.RawQuery += "&"
+		}
+
+		u.RawQuery += data
+		p.cmd.URL = u.String()
+	case len(p.data) != 0:
+		p.cmd.Body = []byte(data)
+
+		if !p.hasHeader("Content-Type") {
+			p.addHeader("Content-Type", "application/x-www-form-urlencoded")
+		}
+	}
+
+	if p.cmd.Method != "" {
+		return nil
+	}
+
+	switch {
+	case p.head:
+		p.cmd.Method = http.MethodHead
+	case p.get:
+		p.cmd.Method = http.MethodGet
+	case len(p.data) != 0 || len(p.cmd.Forms) != 0:
+		p.cmd.Method = http.MethodPost
+	default:
+		p.cmd.Method = http.MethodGet
+	}
+
+	return nil
+}
+
+// readData returns data or content of file, if data starts with @
+// Curl removes new lines from file in text mode.
+func readData(value string, text bool) (string, error) {
+	if !strings.HasPrefix(value, "@") {
+		return value, nil
+	}
+
+	data, err := os.ReadFile(value[1:])
+	if err != nil {
+		return "", fmt.Errorf("could not read data from %v error: '%s'", value[1:], err)
+	}
+
+	if text {
+		return strings.NewReplacer("\r", "", "\n", "").Replace(string(data)), nil
+	}
+
+	return string(data), nil
+}
+
+// urlEncodeData encodes data like curl: content, =content, name=content
+func urlEncodeData(value string) string {
+	name, content, ok := strings.Cut(value, "=")
+	if !ok {
+		return url.QueryEscape(value)
+	}
+
+	if name == "" {
+		return url.QueryEscape(content)
+	}
+
+	return name + "=" + url.QueryEscape(content)
+}
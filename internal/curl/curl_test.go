@@ -0,0 +1,93 @@
+package curl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplit(t *testing.T) {
+	args, err := Split(`curl -H 'A: it'\''s' "B: \"x\" \$y" $'line\nnext\x21' \
+  plain\ arg`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"curl", "-H", "A: it's", `B: "x" $y`, "line\nnext!", "plain arg"}, args)
+
+	_, err = Split(`curl 'unterminated`)
+	require.Error(t, err)
+}
+
+func TestParse(t *testing.T) {
+	cmd, err := Parse(`curl -sSL -X 'PUT' -H 'Content-Type: application/json' -H 'X-Id: 1' -H 'X-Id: 2' --data-raw '{"a":1}' 'https://example.com/users?x=1'`)
+	require.NoError(t, err)
+
+	require.Equal(t, "PUT", cmd.Method)
+	require.Equal(t, "https://example.com/users?x=1", cmd.URL)
+	require.Equal(t, []Header{
+		{Name: "Content-Type", Value: "application/json"},
+		{Name: "X-Id", Value: "1"},
+		{Name: "X-Id", Value: "2"},
+	}, cmd.Headers)
+	require.Equal(t, `{"a":1}`, string(cmd.Body))
+}
+
+func TestParseData(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "body.txt")
+	require.NoError(t, os.WriteFile(file, []byte("a=1\nb=2\n"), 0600))
+
+	cmd, err := Parse(`curl example.com -d @` + file + ` --data-urlencode 'c=x y'`)
+	require.NoError(t, err)
+	require.Equal(t, "POST", cmd.Method)
+	require.Equal(t, "http://example.com", cmd.URL)
+	require.Equal(t, "a=1b=2&c=x+y", string(cmd.Body))
+	require.Equal(t, []Header{{Name: "Content-Type", Value: "application/x-www-form-urlencoded"}}, cmd.Headers)
+
+	cmd, err = Parse(`curl --data-binary @` + file + ` example.com`)
+	require.NoError(t, err)
+	require.Equal(t, "a=1\nb=2\n", string(cmd.Body))
+
+	cmd, err = Parse(`curl -G -d q=cute -d page=2 'example.com/search?lang=go'`)
+	require.NoError(t, err)
+	require.Equal(t, "GET", cmd.Method)
+	require.Equal(t, "http://example.com/search?lang=go&q=cute&page=2", cmd.URL)
+	require.Nil(t, cmd.Body)
+
+	cmd, err = Parse(`curl --json '{"a":1}' example.com`)
+	require.NoError(t, err)
+	require.Equal(t, "POST", cmd.Method)
+	require.Equal(t, []Header{{Name: "Content-Type", Value: "application/json"}, {Name: "Accept", Value: "application/json"}}, cmd.Headers)
+}
+
+func TestParseFormsAndAuth(t *testing.T) {
+	cmd, err := Parse(`curl -u user:pass -b 'session=1' -A cute -F 'file=@photo.png;type=image/png;filename=avatar.png' -F name=cute --form-string 'raw=@value' example.com/upload`)
+	require.NoError(t, err)
+
+	require.Equal(t, "POST", cmd.Method)
+	require.Equal(t, []Header{
+		{Name: "Authorization", Value: "Basic dXNlcjpwYXNz"},
+		{Name: "Cookie", Value: "session=1"},
+		{Name: "User-Agent", Value: "cute"},
+	}, cmd.Headers)
+	require.Equal(t, []Form{
+		{Name: "file", File: "photo.png", FileName: "avatar.png"},
+		{Name: "name", Value: "cute"},
+		{Name: "raw", Value: "@value"},
+	}, cmd.Forms)
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, command := range []string{
+		`wget example.com`,
+		`curl -X POST`,
+		`curl --unknown example.com`,
+		`curl -H`,
+		`curl -H 'no colon' example.com`,
+		`curl -d a=1 -F b=2 example.com`,
+		`curl -d @not_existing_file example.com`,
+	} {
+		_, err := Parse(command)
+		require.Error(t, err, command)
+	}
+}
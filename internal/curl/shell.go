@@ -0,0 +1,188 @@
+package curl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Split is a function for split command to arguments like POSIX shell
+// Supported quotes are '...', "..." and $'...', backslash escapes and line continuations.
+func Split(command string) ([]string, error) {
+	var (
+		args    = make([]string, 0)
+		current strings.Builder
+		inArg   bool
+		runes   = []rune(command)
+	)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+
+				inArg = false
+			}
+		case r == '\\':
+			inArg = true
+
+			if i+1 < len(runes) {
+				i++
+
+				// line continuation
+				if runes[i] == '\n' {
+					inArg = current.Len() != 0
+
+					continue
+				}
+
+				current.WriteRune(runes[i])
+			}
+		case r == '\'':
+			inArg = true
+
+			end := indexRune(runes, i+1, '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated single quote")
+			}
+
+			current.WriteString(string(runes[i+1 : end]))
+			i = end
+		case r == '$' && i+1 < len(runes) && runes[i+1] == '\'':
+			inArg = true
+
+			s, end, err := ansiQuoted(runes, i+2)
+			if err != nil {
+				return nil, err
+			}
+
+			current.WriteString(s)
+			i = end
+		case r == '"':
+			inArg = true
+
+			s, end, err := doubleQuoted(runes, i+1)
+			if err != nil {
+				return nil, err
+			}
+
+			current.WriteString(s)
+			i = end
+		default:
+			inArg = true
+
+			current.WriteRune(r)
+		}
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
+
+func indexRune(runes []rune, from int, r rune) int {
+	for i := from; i < len(runes); i++ {
+		if runes[i] == r {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// doubleQuoted returns content of "..." and index of closing quote
+func doubleQuoted(runes []rune, from int) (string, int, error) {
+	var b strings.Builder
+
+	for i := from; i < len(runes); i++ {
+		switch runes[i] {
+		case '"':
+			return b.String(), i, nil
+		case '\\':
+			if i+1 < len(runes) {
+				next := runes[i+1]
+
+				switch next {
+				case '"', '\\', '$', '`':
+					b.WriteRune(next)
+					i++
+
+					continue
+				case '\n':
+					i++
+
+					continue
+				}
+			}
+
+			b.WriteRune('\\')
+		default:
+			b.WriteRune(runes[i])
+		}
+	}
+
+	return "", 0, fmt.Errorf("unterminated double quote")
+}
+
+// ansiQuoted returns content of $'...' with C escapes and index of closing quote
+func ansiQuoted(runes []rune, from int) (string, int, error) {
+	var b strings.Builder
+
+	for i := from; i < len(runes); i++ {
+		switch runes[i] {
+		case '\'':
+			return b.String(), i, nil
+		case '\\':
+			if i+1 >= len(runes) {
+				return "", 0, fmt.Errorf("unterminated ansi quote")
+			}
+
+			i++
+
+			switch runes[i] {
+			case 'n':
+				b.WriteRune('\n')
+			case 't':
+				b.WriteRune('\t')
+			case 'r':
+				b.WriteRune('\r')
+			case '0':
+				b.WriteRune(0)
+			case 'x', 'u':
+				size := 2
+				if runes[i] == 'u' {
+					size = 4
+				}
+
+				if i+size >= len(runes) {
+					return "", 0, fmt.Errorf("could not parse escape in ansi quote")
+				}
+
+				code, err := strconv.ParseUint(string(runes[i+1:i+1+size]), 16, 32)
+				if err != nil {
+					return "", 0, fmt.Errorf("could not parse escape in ansi quote")
+				}
+
+				if size == 2 {
+					b.WriteByte(byte(code))
+				} else {
+					b.WriteRune(rune(code))
+				}
+
+				i += size
+			default:
+				b.WriteRune(runes[i])
+			}
+		default:
+			b.WriteRune(runes[i])
+		}
+	}
+
+	return "", 0, fmt.Errorf("unterminated ansi quote")
+}
@@ -0,0 +1,64 @@
+// Package jsondiff compares JSON documents and reports difference as assert error
+package jsondiff
+
+import (
+	"fmt"
+
+	jd "github.com/josephburnett/jd/lib"
+	"github.com/ohler55/ojg/jp"
+	"github.com/ohler55/ojg/oj"
+
+	cuteErrors "github.com/ozontech/cute/errors"
+)
+
+// Compare returns assert error with diff in attachment, if JSON are not the same
+// Values by ignore jsonpath expressions are removed from both JSON before comparison, for example $.created_at.
+func Compare(original, body []byte, ignore ...string) error {
+	originalJSON, err := read(original, ignore)
+	if err != nil {
+		return fmt.Errorf("could not parse original json in Diff error: '%s'", err)
+	}
+
+	bodyJSON, err := read(body, ignore)
+	if err != nil {
+		return fmt.Errorf("could not parse body json in Diff error: '%s'", err)
+	}
+
+	diff := originalJSON.Diff(bodyJSON).Render()
+	if diff != "" {
+		cErr := cuteErrors.NewEmptyAssertError("JSON Diff", "JSON is not the same")
+		cErr.PutAttachment(&cuteErrors.Attachment{
+			Name:     "JSON diff",
+			MimeType: "text/plain",
+			Content:  []byte(diff),
+		})
+
+		return cErr
+	}
+
+	return nil
+}
+
+func read(data []byte, ignore []string) (jd.JsonNode, error) {
+	if len(ignore) == 0 {
+		return jd.ReadJsonString(string(data))
+	}
+
+	obj, err := oj.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range ignore {
+		expr, err := jp.ParseString(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse ignored path %v: %w", path, err)
+		}
+
+		if obj, err = expr.Remove(obj); err != nil {
+			return nil, fmt.Errorf("could not remove ignored path %v: %w", path, err)
+		}
+	}
+
+	return jd.ReadJsonString(oj.JSON(obj))
+}
@@ -0,0 +1,21 @@
+package jsondiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	var (
+		original = []byte(`{"id": 1, "request_id": "a", "items": [{"id": 1, "at": "now"}]}`)
+		body     = []byte(`{"id": 1, "request_id": "b", "items": [{"id": 1, "at": "later"}]}`)
+	)
+
+	require.ErrorContains(t, Compare(original, body), "JSON is not the same")
+	require.NoError(t, Compare(original, body, "$.request_id", "$.items[*].at"))
+	require.ErrorContains(t, Compare(original, []byte(`{"id": 2}`), "$.request_id", "$.items"), "JSON is not the same")
+
+	require.ErrorContains(t, Compare(original, body, "$.["), "could not parse ignored path $.[")
+	require.ErrorContains(t, Compare([]byte(`{`), body), "could not parse original json in Diff error")
+}
@@ -0,0 +1,419 @@
+// Package openapi is a minimal model of OpenAPI 3 document, which is enough for generate and check tests.
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Methods is a list of http methods, which could be used in path item
+var Methods = []string{
+	http.MethodGet,
+	http.MethodPut,
+	http.MethodPost,
+	http.MethodDelete,
+	http.MethodOptions,
+	http.MethodHead,
+	http.MethodPatch,
+	http.MethodTrace,
+}
+
+// Document is OpenAPI 3.0 or 3.1 document
+type Document struct {
+	OpenAPI    string              `yaml:"openapi"`
+	Info       Info                `yaml:"info"`
+	Servers    []Server            `yaml:"servers"`
+	Paths      map[string]PathItem `yaml:"paths"`
+	Components Components          `yaml:"components"`
+}
+
+// Info is information about API
+type Info struct {
+	Title   string `yaml:"title"`
+	Version string `yaml:"version"`
+}
+
+// Server is a server of API
+type Server struct {
+	URL string `yaml:"url"`
+}
+
+// PathItem is a set of operations of path
+type PathItem struct {
+	Parameters []Parameter `yaml:"parameters"`
+	Get        *Operation  `yaml:"get"`
+	Put        *Operation  `yaml:"put"`
+	Post       *Operation  `yaml:"post"`
+	Delete     *Operation  `yaml:"delete"`
+	Options    *Operation  `yaml:"options"`
+	Head       *Operation  `yaml:"head"`
+	Patch      *Operation  `yaml:"patch"`
+	Trace      *Operation  `yaml:"trace"`
+}
+
+// Operation is an API operation
+type Operation struct {
+	OperationID string              `yaml:"operationId"`
+	Summary     string              `yaml:"summary"`
+	Description string              `yaml:"description"`
+	Tags        []string            `yaml:"tags"`
+	Deprecated  bool                `yaml:"deprecated"`
+	Parameters  []Parameter         `yaml:"parameters"`
+	RequestBody *RequestBody        `yaml:"requestBody"`
+	Responses   map[string]Response `yaml:"responses"`
+}
+
+// Parameter is a path, query, header or cookie parameter
+type Parameter struct {
+	Ref      string             `yaml:"$ref"`
+	Name     string             `yaml:"name"`
+	In       string             `yaml:"in"`
+	Required bool               `yaml:"required"`
+	Schema   interface{}        `yaml:"schema"`
+	Example  interface{}        `yaml:"example"`
+	Examples map[string]Example `yaml:"examples"`
+}
+
+// RequestBody is a body of request
+type RequestBody struct {
+	Ref      string               `yaml:"$ref"`
+	Required bool                 `yaml:"required"`
+	Content  map[string]MediaType `yaml:"content"`
+}
+
+// Response is a response of operation
+type Response struct {
+	Ref         string               `yaml:"$ref"`
+	Description string               `yaml:"description"`
+	Content     map[string]MediaType `yaml:"content"`
+}
+
+// MediaType is a content of body
+type MediaType struct {
+	Schema   interface{}        `yaml:"schema"`
+	Example  interface{}        `yaml:"example"`
+	Examples map[string]Example `yaml:"examples"`
+}
+
+// Example is an example of value
+type Example struct {
+	Ref   string      `yaml:"$ref"`
+	Value interface{} `yaml:"value"`
+}
+
+// Components is a set of reusable objects
+type Components struct {
+	Schemas       map[string]interface{} `yaml:"schemas"`
+	Parameters    map[string]Parameter   `yaml:"parameters"`
+	RequestBodies map[string]RequestBody `yaml:"requestBodies"`
+	Responses     map[string]Response    `yaml:"responses"`
+	Examples      map[string]Example     `yaml:"examples"`
+}
+
+// MethodOperation is an operation with method and path
+type MethodOperation struct {
+	Method string
+	Path   string
+	// Parameters contains parameters of path item and operation, references are resolved
+	Parameters []Parameter
+	*Operation
+}
+
+// Load is a function for load document from JSON or YAML file
+func Load(path string) (*Document, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read openapi document error: '%s'", err)
+	}
+
+	return Parse(data)
+}
+
+// Parse is a function for parse document from JSON or YAML
+func Parse(data []byte) (*Document, error) {
+	doc := new(Document)
+
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("could not parse openapi document error: '%s'", err)
+	}
+
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("could not parse openapi document error: 'unsupported version %q'", doc.OpenAPI)
+	}
+
+	return doc, nil
+}
+
+// Operations returns all operations sorted by path and method
+func (d *Document) Operations() ([]MethodOperation, error) {
+	paths := make([]string, 0, len(d.Paths))
+	for p := range d.Paths {
+		paths = append(paths, p)
+	}
+
+	sort.Strings(paths)
+
+	res := make([]MethodOperation, 0, len(paths))
+
+	for _, p := range paths {
+		item := d.Paths[p]
+
+		for _, method := range Methods {
+			op := item.operation(method)
+			if op == nil {
+				continue
+			}
+
+			params, err := d.parameters(item.Parameters, op.Parameters)
+			if err != nil {
+				return nil, fmt.Errorf("%v %v: %w", method, p, err)
+			}
+
+			res = append(res, MethodOperation{
+				Method:     method,
+				Path:       p,
+				Parameters: params,
+				Operation:  op,
+			})
+		}
+	}
+
+	return res, nil
+}
+
+func (p PathItem) operation(method string) *Operation {
+	switch method {
+	case http.MethodGet:
+		return p.Get
+	case http.MethodPut:
+		return p.Put
+	case http.MethodPost:
+		return p.Post
+	case http.MethodDelete:
+		return p.Delete
+	case http.MethodOptions:
+		return p.Options
+	case http.MethodHead:
+		return p.Head
+	case http.MethodPatch:
+		return p.Patch
+	case http.MethodTrace:
+		return p.Trace
+	default:
+		return nil
+	}
+}
+
+// parameters merges parameters of path item and operation, parameter of operation overrides parameter of path
+func (d *Document) parameters(pathParams, opParams []Parameter) ([]Parameter, error) {
+	var (
+		res   = make([]Parameter, 0, len(pathParams)+len(opParams))
+		index = make(map[string]int)
+	)
+
+	for _, param := range append(append([]Parameter{}, pathParams...), opParams...) {
+		resolved, err := d.Parameter(param)
+		if err != nil {
+			return nil, err
+		}
+
+		key := resolved.In + ":" + resolved.Name
+
+		if i, ok := index[key]; ok {
+			res[i] = resolved
+
+			continue
+		}
+
+		index[key] = len(res)
+		res = append(res, resolved)
+	}
+
+	return res, nil
+}
+
+// Parameter returns parameter, reference is resolved
+func (d *Document) Parameter(param Parameter) (Parameter, error) {
+	if param.Ref == "" {
+		return param, nil
+	}
+
+	name, err := componentName(param.Ref, "parameters")
+	if err != nil {
+		return Parameter{}, err
+	}
+
+	resolved, ok := d.Components.Parameters[name]
+	if !ok {
+		return Parameter{}, fmt.Errorf("reference %v is not found", param.Ref)
+	}
+
+	return d.Parameter(resolved)
+}
+
+// RequestBody returns request body, reference is resolved
+func (d *Document) RequestBody(body *RequestBody) (*RequestBody, error) {
+	if body == nil || body.Ref == "" {
+		return body, nil
+	}
+
+	name, err := componentName(body.Ref, "requestBodies")
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, ok := d.Components.RequestBodies[name]
+	if !ok {
+		return nil, fmt.Errorf("reference %v is not found", body.Ref)
+	}
+
+	return d.RequestBody(&resolved)
+}
+
+// Response returns response, reference is resolved
+func (d *Document) Response(response Response) (Response, error) {
+	if response.Ref == "" {
+		return response, nil
+	}
+
+	name, err := componentName(response.Ref, "responses")
+	if err != nil {
+		return Response{}, err
+	}
+
+	resolved, ok := d.Components.Responses[name]
+	if !ok {
+		return Response{}, fmt.Errorf("reference %v is not found", response.Ref)
+	}
+
+	return d.Response(resolved)
+}
+
+// ExampleValue returns first example of value: example, first of examples by name or example of schema
+func (d *Document) ExampleValue(example interface{}, examples map[string]Example, schema interface{}) (interface{}, bool) {
+	if example != nil {
+		return example, true
+	}
+
+	names := make([]string, 0, len(examples))
+	for name := range examples {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		e := examples[name]
+
+		if e.Ref != "" {
+			component, err := componentName(e.Ref, "examples")
+			if err != nil {
+				continue
+			}
+
+			e = d.Components.Examples[component]
+		}
+
+		if e.Value != nil {
+			return e.Value, true
+		}
+	}
+
+	if s, ok := d.resolveSchema(schema).(map[string]interface{}); ok {
+		if v, ok := s["example"]; ok {
+			return v, true
+		}
+
+		if v, ok := s["default"]; ok {
+			return v, true
+		}
+
+		if enum, ok := s["enum"].([]interface{}); ok && len(enum) != 0 {
+			return enum[0], true
+		}
+	}
+
+	return nil, false
+}
+
+// SchemaType returns type of schema, for example string or integer
+func (d *Document) SchemaType(schema interface{}) string {
+	s, ok := d.resolveSchema(schema).(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	switch typ := s["type"].(type) {
+	case string:
+		return typ
+	case []interface{}:
+		for _, t := range typ {
+			if t != "null" {
+				return fmt.Sprint(t)
+			}
+		}
+	}
+
+	return ""
+}
+
+func (d *Document) resolveSchema(schema interface{}) interface{} {
+	for i := 0; i < 32; i++ {
+		s, ok := schema.(map[string]interface{})
+		if !ok {
+			return schema
+		}
+
+		ref, ok := s["$ref"].(string)
+		if !ok {
+			return schema
+		}
+
+		name, err := componentName(ref, "schemas")
+		if err != nil {
+			return schema
+		}
+
+		schema = d.Components.Schemas[name]
+	}
+
+	return schema
+}
+
+// JSONMediaType returns media type with JSON content
+func JSONMediaType(content map[string]MediaType) (MediaType, bool) {
+	types := make([]string, 0, len(content))
+	for t := range content {
+		types = append(types, t)
+	}
+
+	sort.Strings(types)
+
+	for _, t := range types {
+		mediaType := strings.TrimSpace(strings.Split(t, ";")[0])
+		if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+			return content[t], true
+		}
+	}
+
+	return MediaType{}, false
+}
+
+func componentName(ref, kind string) (string, error) {
+	prefix := "#/components/" + kind + "/"
+
+	if !strings.HasPrefix(ref, prefix) {
+		return "", fmt.Errorf("reference %v is not supported, only local references to %v", ref, prefix)
+	}
+
+	return unescapePointer(strings.TrimPrefix(ref, prefix)), nil
+}
+
+func unescapePointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~1", "/"), "~0", "~")
+}
@@ -0,0 +1,188 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/require"
+)
+
+const petstore = `
+openapi: 3.0.3
+info:
+  title: Petstore
+  version: 1.0.0
+servers:
+  - url: https://petstore.example.com/v1
+paths:
+  /pets/{petId}:
+    parameters:
+      - $ref: '#/components/parameters/PetID'
+    get:
+      operationId: getPet
+      tags: [pets]
+      parameters:
+        - name: X-Request-ID
+          in: header
+          required: true
+          schema:
+            type: string
+            format: uuid
+      responses:
+        '200':
+          description: pet
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        '404':
+          $ref: '#/components/responses/NotFound'
+  /pets:
+    post:
+      summary: Create pet
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        '201':
+          description: created
+components:
+  parameters:
+    PetID:
+      name: petId
+      in: path
+      required: true
+      schema:
+        type: integer
+      example: 42
+  responses:
+    NotFound:
+      description: not found
+  schemas:
+    Pet:
+      type: object
+      required: [id, name]
+      properties:
+        id:
+          type: integer
+          format: int64
+        name:
+          type: string
+        tag:
+          type: string
+          nullable: true
+        owner:
+          $ref: '#/components/schemas/Owner'
+    Owner:
+      type: object
+      required: [email]
+      properties:
+        email:
+          type: string
+          format: email
+        pets:
+          type: array
+          items:
+            $ref: '#/components/schemas/Pet'
+`
+
+func TestOperations(t *testing.T) {
+	doc, err := Parse([]byte(petstore))
+	require.NoError(t, err)
+
+	ops, err := doc.Operations()
+	require.NoError(t, err)
+	require.Len(t, ops, 2)
+
+	require.Equal(t, "POST", ops[0].Method)
+	require.Equal(t, "/pets", ops[0].Path)
+
+	require.Equal(t, "GET", ops[1].Method)
+	require.Equal(t, "/pets/{petId}", ops[1].Path)
+	require.Len(t, ops[1].Parameters, 2)
+	require.Equal(t, "petId", ops[1].Parameters[0].Name)
+
+	v, ok := doc.ExampleValue(ops[1].Parameters[0].Example, nil, nil)
+	require.True(t, ok)
+	require.Equal(t, 42, v)
+
+	resp, err := doc.Response(ops[1].Responses["404"])
+	require.NoError(t, err)
+	require.Equal(t, "not found", resp.Description)
+}
+
+func TestParseUnsupportedVersion(t *testing.T) {
+	_, err := Parse([]byte(`swagger: "2.0"`))
+	require.Error(t, err)
+}
+
+func TestJSONSchema(t *testing.T) {
+	doc, err := Parse([]byte(petstore))
+	require.NoError(t, err)
+
+	schema, err := doc.JSONSchema(map[string]interface{}{"$ref": "#/components/schemas/Pet"})
+	require.NoError(t, err)
+
+	compiled := compile(t, schema)
+
+	valid := `{"id": 1, "name": "cat", "tag": null, "owner": {"email": "a@example.com", "pets": [{"id": 2, "name": "dog"}]}}`
+	require.NoError(t, compiled.Validate(unmarshal(t, valid)))
+
+	require.Error(t, compiled.Validate(unmarshal(t, `{"id": 1}`)))
+	require.Error(t, compiled.Validate(unmarshal(t, `{"id": 1, "name": "cat", "owner": {"email": "not email"}}`)))
+}
+
+func TestJSONSchema31(t *testing.T) {
+	doc := &Document{
+		OpenAPI: "3.1.0",
+		Components: Components{Schemas: map[string]interface{}{
+			"Point": map[string]interface{}{
+				"type":        "array",
+				"prefixItems": []interface{}{map[string]interface{}{"type": "number"}},
+				"items":       false,
+			},
+		}},
+	}
+
+	schema, err := doc.JSONSchema(map[string]interface{}{"$ref": "#/components/schemas/Point"})
+	require.NoError(t, err)
+
+	compiled := compile(t, schema)
+
+	require.NoError(t, compiled.Validate(unmarshal(t, `[1]`)))
+	require.Error(t, compiled.Validate(unmarshal(t, `[1, 2]`)))
+}
+
+func TestSampleValue(t *testing.T) {
+	doc, err := Parse([]byte(petstore))
+	require.NoError(t, err)
+
+	v := doc.SampleValue(map[string]interface{}{"$ref": "#/components/schemas/Pet"})
+	require.Equal(t, map[string]interface{}{"id": 0, "name": "string"}, v)
+}
+
+func compile(t *testing.T, schema []byte) *jsonschema.Schema {
+	c := jsonschema.NewCompiler()
+	c.AssertFormat()
+
+	require.NoError(t, c.AddResource("schema.json", unmarshal(t, string(schema))))
+
+	compiled, err := c.Compile("schema.json")
+	require.NoError(t, err)
+
+	return compiled
+}
+
+func unmarshal(t *testing.T, s string) interface{} {
+	require.True(t, json.Valid([]byte(s)))
+
+	v, err := jsonschema.UnmarshalJSON(bytes.NewReader([]byte(s)))
+	require.NoError(t, err)
+
+	return v
+}
@@ -0,0 +1,113 @@
+package openapi
+
+import "sort"
+
+const maxSampleDepth = 8
+
+// SampleValue returns value which matches schema: example of schema or minimal value by type.
+// Only required properties of object are filled.
+func (d *Document) SampleValue(schema interface{}) interface{} {
+	return d.sampleValue(schema, 0)
+}
+
+func (d *Document) sampleValue(schema interface{}, depth int) interface{} {
+	if depth > maxSampleDepth {
+		return nil
+	}
+
+	if v, ok := d.ExampleValue(nil, nil, schema); ok {
+		return v
+	}
+
+	s, ok := d.resolveSchema(schema).(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	for _, key := range []string{"allOf", "oneOf", "anyOf"} {
+		items, isList := s[key].([]interface{})
+		if !isList || len(items) == 0 {
+			continue
+		}
+
+		if key != "allOf" {
+			return d.sampleValue(items[0], depth+1)
+		}
+
+		merged := make(map[string]interface{})
+
+		for _, item := range items {
+			if obj, isObject := d.sampleValue(item, depth+1).(map[string]interface{}); isObject {
+				for k, v := range obj {
+					merged[k] = v
+				}
+			}
+		}
+
+		return merged
+	}
+
+	switch d.SchemaType(s) {
+	case "string":
+		return sampleString(s)
+	case "integer", "number":
+		if minimum, isNumber := s["minimum"]; isNumber {
+			return minimum
+		}
+
+		return 0
+	case "boolean":
+		return false
+	case "array":
+		return []interface{}{}
+	case "null":
+		return nil
+	default:
+		return d.sampleObject(s, depth)
+	}
+}
+
+func (d *Document) sampleObject(s map[string]interface{}, depth int) map[string]interface{} {
+	var (
+		res        = make(map[string]interface{})
+		properties = make(map[string]interface{})
+		required   = make([]string, 0)
+	)
+
+	if p, ok := s["properties"].(map[string]interface{}); ok {
+		properties = p
+	}
+
+	if r, ok := s["required"].([]interface{}); ok {
+		for _, name := range r {
+			if n, isString := name.(string); isString {
+				required = append(required, n)
+			}
+		}
+	}
+
+	sort.Strings(required)
+
+	for _, name := range required {
+		res[name] = d.sampleValue(properties[name], depth+1)
+	}
+
+	return res
+}
+
+func sampleString(s map[string]interface{}) string {
+	switch s["format"] {
+	case "date-time":
+		return "2024-01-01T00:00:00Z"
+	case "date":
+		return "2024-01-01"
+	case "email":
+		return "user@example.com"
+	case "uuid":
+		return "00000000-0000-0000-0000-000000000000"
+	case "uri", "url":
+		return "https://example.com"
+	default:
+		return "string"
+	}
+}
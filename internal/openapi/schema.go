@@ -0,0 +1,180 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const schemasRef = "#/components/schemas/"
+
+// JSONSchema converts schema of document to standalone JSON schema.
+// Referenced components are placed to definitions of result schema.
+// Schema of OpenAPI 3.0 is converted to draft 4 with nullable as null type, schema of OpenAPI 3.1 is draft 2020-12.
+func (d *Document) JSONSchema(schema interface{}) ([]byte, error) {
+	var (
+		draft   = "http://json-schema.org/draft-04/schema#"
+		defsKey = "definitions"
+	)
+
+	if strings.HasPrefix(d.OpenAPI, "3.1") {
+		draft = "https://json-schema.org/draft/2020-12/schema"
+		defsKey = "$defs"
+	}
+
+	c := &schemaConverter{
+		defsKey: defsKey,
+		legacy:  !strings.HasPrefix(d.OpenAPI, "3.1"),
+		defs:    make(map[string]interface{}),
+		queue:   make([]string, 0),
+		seen:    make(map[string]bool),
+	}
+
+	root, err := c.convert(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	for len(c.queue) != 0 {
+		name := c.queue[0]
+		c.queue = c.queue[1:]
+
+		component, ok := d.Components.Schemas[name]
+		if !ok {
+			return nil, fmt.Errorf("reference %v%v is not found", schemasRef, name)
+		}
+
+		def, err := c.convert(component)
+		if err != nil {
+			return nil, err
+		}
+
+		c.defs[name] = def
+	}
+
+	res, ok := root.(map[string]interface{})
+	if !ok {
+		// boolean schema
+		res = map[string]interface{}{"allOf": []interface{}{root}}
+	}
+
+	// keywords near $ref are ignored by old drafts
+	if _, isRef := res["$ref"]; isRef {
+		res = map[string]interface{}{"allOf": []interface{}{res}}
+	}
+
+	res["$schema"] = draft
+
+	if len(c.defs) != 0 {
+		res[defsKey] = c.defs
+	}
+
+	return json.Marshal(res)
+}
+
+type schemaConverter struct {
+	defsKey string
+	legacy  bool
+	defs    map[string]interface{}
+	queue   []string
+	seen    map[string]bool
+}
+
+func (c *schemaConverter) convert(v interface{}) (interface{}, error) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(value))
+
+		for key, item := range value {
+			converted, err := c.convert(item)
+			if err != nil {
+				return nil, err
+			}
+
+			res[key] = converted
+		}
+
+		if ref, ok := res["$ref"].(string); ok {
+			name, err := componentName(ref, "schemas")
+			if err != nil {
+				return nil, err
+			}
+
+			c.enqueue(name)
+			res["$ref"] = "#/" + c.defsKey + "/" + escapePointer(name)
+		}
+
+		if c.legacy {
+			convertNullable(res)
+		}
+
+		return res, nil
+	case map[interface{}]interface{}:
+		res := make(map[string]interface{}, len(value))
+		for key, item := range value {
+			res[fmt.Sprint(key)] = item
+		}
+
+		return c.convert(res)
+	case []interface{}:
+		res := make([]interface{}, 0, len(value))
+
+		for _, item := range value {
+			converted, err := c.convert(item)
+			if err != nil {
+				return nil, err
+			}
+
+			res = append(res, converted)
+		}
+
+		return res, nil
+	default:
+		return v, nil
+	}
+}
+
+func (c *schemaConverter) enqueue(name string) {
+	if c.seen[name] {
+		return
+	}
+
+	c.seen[name] = true
+	c.queue = append(c.queue, name)
+}
+
+// convertNullable converts nullable of OpenAPI 3.0 to null type
+func convertNullable(schema map[string]interface{}) {
+	nullable, ok := schema["nullable"].(bool)
+	if !ok {
+		return
+	}
+
+	delete(schema, "nullable")
+
+	if !nullable {
+		return
+	}
+
+	switch typ := schema["type"].(type) {
+	case string:
+		schema["type"] = []interface{}{typ, "null"}
+	case nil:
+		if _, isRef := schema["$ref"]; isRef {
+			ref := map[string]interface{}{"$ref": schema["$ref"]}
+			delete(schema, "$ref")
+
+			schema["anyOf"] = []interface{}{ref, map[string]interface{}{"type": "null"}}
+		}
+
+		return
+	}
+
+	if enum, isEnum := schema["enum"].([]interface{}); isEnum {
+		schema["enum"] = append(enum, nil)
+	}
+}
+
+func escapePointer(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
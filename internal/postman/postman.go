@@ -0,0 +1,379 @@
+// Package postman is a model of Postman v2.1 collection and environment.
+package postman
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Collection is a Postman v2.1 collection
+type Collection struct {
+	Info     Info       `json:"info"`
+	Item     []Item     `json:"item"`
+	Variable []Variable `json:"variable"`
+	Event    []Event    `json:"event"`
+	Auth     *Auth      `json:"auth"`
+}
+
+// Info is information about collection
+type Info struct {
+	Name        string `json:"name"`
+	Description Text   `json:"description"`
+	Schema      string `json:"schema"`
+}
+
+// Item is a folder, if Item is not empty, or a request
+type Item struct {
+	Name        string     `json:"name"`
+	Description Text       `json:"description"`
+	Item        []Item     `json:"item"`
+	Request     *Request   `json:"request"`
+	Event       []Event    `json:"event"`
+	Variable    []Variable `json:"variable"`
+	Auth        *Auth      `json:"auth"`
+}
+
+// IsFolder returns true, if item is a folder
+func (i Item) IsFolder() bool {
+	return i.Request == nil
+}
+
+// Request is a request of item
+type Request struct {
+	Method      string   `json:"method"`
+	URL         URL      `json:"url"`
+	Header      []Header `json:"header"`
+	Body        *Body    `json:"body"`
+	Auth        *Auth    `json:"auth"`
+	Description Text     `json:"description"`
+}
+
+// UnmarshalJSON supports request as string with url
+func (r *Request) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err == nil {
+		r.Method = "GET"
+		r.URL = URL{Raw: raw}
+
+		return nil
+	}
+
+	type request Request
+
+	return json.Unmarshal(data, (*request)(r))
+}
+
+// URL is an url of request
+type URL struct {
+	Raw      string     `json:"raw"`
+	Host     []string   `json:"host"`
+	Path     []string   `json:"path"`
+	Query    []KeyValue `json:"query"`
+	Variable []KeyValue `json:"variable"`
+}
+
+// UnmarshalJSON supports url as string
+func (u *URL) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err == nil {
+		u.Raw = raw
+
+		return nil
+	}
+
+	type url URL
+
+	return json.Unmarshal(data, (*url)(u))
+}
+
+// String returns url with enabled query parameters, path variables like :id are replaced by values
+func (u URL) String() string {
+	if len(u.Host) == 0 {
+		return u.replacePathVariables(u.Raw)
+	}
+
+	res := strings.Join(u.Host, ".")
+	if len(u.Path) != 0 {
+		res += "/" + u.replacePathVariables(strings.Join(u.Path, "/"))
+	}
+
+	query := make([]string, 0, len(u.Query))
+
+	for _, q := range u.Query {
+		if q.Disabled {
+			continue
+		}
+
+		query = append(query, q.Key+"="+q.Value)
+	}
+
+	if len(query) != 0 {
+		res += "?" + strings.Join(query, "&")
+	}
+
+	return res
+}
+
+func (u URL) replacePathVariables(s string) string {
+	for _, v := range u.Variable {
+		if v.Value == "" {
+			continue
+		}
+
+		segments := strings.Split(s, "/")
+		for i, segment := range segments {
+			if segment == ":"+v.Key {
+				segments[i] = v.Value
+			}
+		}
+
+		s = strings.Join(segments, "/")
+	}
+
+	return s
+}
+
+// Header is a header of request
+type Header struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Disabled bool   `json:"disabled"`
+}
+
+// KeyValue is a query parameter or urlencoded field
+type KeyValue struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Disabled bool   `json:"disabled"`
+}
+
+// Body is a body of request
+type Body struct {
+	Mode       string          `json:"mode"`
+	Raw        string          `json:"raw"`
+	URLEncoded []KeyValue      `json:"urlencoded"`
+	FormData   []FormParam     `json:"formdata"`
+	GraphQL    *GraphQL        `json:"graphql"`
+	Options    json.RawMessage `json:"options"`
+	Disabled   bool            `json:"disabled"`
+}
+
+// Language returns language of raw body, for example json
+func (b *Body) Language() string {
+	var options struct {
+		Raw struct {
+			Language string `json:"language"`
+		} `json:"raw"`
+	}
+
+	if len(b.Options) == 0 || json.Unmarshal(b.Options, &options) != nil {
+		return ""
+	}
+
+	return options.Raw.Language
+}
+
+// FormParam is a field of multipart form
+type FormParam struct {
+	Key      string          `json:"key"`
+	Value    string          `json:"value"`
+	Type     string          `json:"type"`
+	Src      json.RawMessage `json:"src"`
+	Disabled bool            `json:"disabled"`
+}
+
+// File returns path of file of form field
+func (f FormParam) File() string {
+	var src string
+	if json.Unmarshal(f.Src, &src) == nil {
+		return src
+	}
+
+	var list []string
+	if json.Unmarshal(f.Src, &list) == nil && len(list) != 0 {
+		return list[0]
+	}
+
+	return ""
+}
+
+// GraphQL is a body of graphql request
+type GraphQL struct {
+	Query     string `json:"query"`
+	Variables string `json:"variables"`
+}
+
+// Auth is an authorization of request
+type Auth struct {
+	Type   string     `json:"type"`
+	Bearer []KeyValue `json:"bearer"`
+	Basic  []KeyValue `json:"basic"`
+	APIKey []KeyValue `json:"apikey"`
+}
+
+// Param returns parameter of auth by type and key
+func (a *Auth) Param(key string) string {
+	var params []KeyValue
+
+	switch a.Type {
+	case "bearer":
+		params = a.Bearer
+	case "basic":
+		params = a.Basic
+	case "apikey":
+		params = a.APIKey
+	}
+
+	for _, p := range params {
+		if p.Key == key {
+			return p.Value
+		}
+	}
+
+	return ""
+}
+
+// Event is a script of item
+type Event struct {
+	Listen string `json:"listen"`
+	Script Script `json:"script"`
+}
+
+// Script is a script of event
+type Script struct {
+	Exec Lines `json:"exec"`
+}
+
+// Lines is a list of lines, which could be written as string
+type Lines []string
+
+// UnmarshalJSON supports lines as string
+func (l *Lines) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err == nil {
+		*l = strings.Split(raw, "\n")
+
+		return nil
+	}
+
+	var lines []string
+	if err := json.Unmarshal(data, &lines); err != nil {
+		return err
+	}
+
+	*l = lines
+
+	return nil
+}
+
+// Text is a description, which could be written as string or object with content
+type Text string
+
+// UnmarshalJSON supports text as object with content
+func (t *Text) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err == nil {
+		*t = Text(raw)
+
+		return nil
+	}
+
+	var obj struct {
+		Content string `json:"content"`
+	}
+
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+
+	*t = Text(obj.Content)
+
+	return nil
+}
+
+// Variable is a variable of collection
+type Variable struct {
+	Key      string `json:"key"`
+	Value    any    `json:"value"`
+	Disabled bool   `json:"disabled"`
+}
+
+// Environment is a Postman environment
+type Environment struct {
+	Name   string             `json:"name"`
+	Values []EnvironmentValue `json:"values"`
+}
+
+// EnvironmentValue is a variable of environment
+type EnvironmentValue struct {
+	Key     string `json:"key"`
+	Value   any    `json:"value"`
+	Enabled *bool  `json:"enabled"`
+}
+
+// LoadCollection is a function for load collection from file
+func LoadCollection(path string) (*Collection, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read collection error: '%s'", err)
+	}
+
+	collection := new(Collection)
+
+	if err = json.Unmarshal(data, collection); err != nil {
+		return nil, fmt.Errorf("could not parse collection error: '%s'", err)
+	}
+
+	if collection.Info.Schema != "" && !strings.Contains(collection.Info.Schema, "v2.1") {
+		return nil, fmt.Errorf("could not parse collection error: 'unsupported schema %v, export collection as v2.1'", collection.Info.Schema)
+	}
+
+	return collection, nil
+}
+
+// LoadEnvironment is a function for load environment from file
+func LoadEnvironment(path string) (*Environment, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read environment error: '%s'", err)
+	}
+
+	env := new(Environment)
+
+	if err = json.Unmarshal(data, env); err != nil {
+		return nil, fmt.Errorf("could not parse environment error: '%s'", err)
+	}
+
+	return env, nil
+}
+
+// Variables returns variables of collection and environments, environment overrides collection
+func Variables(collection *Collection, envs ...*Environment) map[string]string {
+	res := make(map[string]string)
+
+	for _, v := range collection.Variable {
+		if !v.Disabled {
+			res[v.Key] = valueString(v.Value)
+		}
+	}
+
+	for _, env := range envs {
+		for _, v := range env.Values {
+			if v.Enabled == nil || *v.Enabled {
+				res[v.Key] = valueString(v.Value)
+			}
+		}
+	}
+
+	return res
+}
+
+func valueString(v any) string {
+	if v == nil {
+		return ""
+	}
+
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,106 @@
+package postman
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const collection = `{
+  "info": {
+    "name": "Users",
+    "schema": "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+  },
+  "variable": [
+    {"key": "baseUrl", "value": "https://go.com"},
+    {"key": "limit", "value": 10},
+    {"key": "disabled", "value": "x", "disabled": true}
+  ],
+  "item": [
+    {
+      "name": "users",
+      "description": {"content": "Users API"},
+      "item": [
+        {
+          "name": "Get user",
+          "request": {
+            "method": "GET",
+            "url": {
+              "raw": "{{baseUrl}}/users/:id?expand=roles",
+              "host": ["{{baseUrl}}"],
+              "path": ["users", ":id"],
+              "query": [{"key": "expand", "value": "roles"}, {"key": "debug", "value": "1", "disabled": true}],
+              "variable": [{"key": "id", "value": "7"}]
+            }
+          },
+          "event": [{"listen": "test", "script": {"exec": "pm.response.to.have.status(200);\npm.response.to.be.ok;"}}]
+        }
+      ]
+    },
+    {"name": "Ping", "request": "{{baseUrl}}/ping"}
+  ]
+}`
+
+const environment = `{
+  "name": "dev",
+  "values": [
+    {"key": "baseUrl", "value": "https://dev.go.com", "enabled": true},
+    {"key": "token", "value": "secret"},
+    {"key": "old", "value": "x", "enabled": false}
+  ]
+}`
+
+func TestLoadCollection(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "collection.json")
+	require.NoError(t, os.WriteFile(path, []byte(collection), 0600))
+
+	c, err := LoadCollection(path)
+	require.NoError(t, err)
+	require.Equal(t, "Users", c.Info.Name)
+	require.Len(t, c.Item, 2)
+
+	folder := c.Item[0]
+	require.True(t, folder.IsFolder())
+	require.Equal(t, Text("Users API"), folder.Description)
+
+	get := folder.Item[0]
+	require.False(t, get.IsFolder())
+	require.Equal(t, "{{baseUrl}}/users/7?expand=roles", get.Request.URL.String())
+	require.Equal(t, Lines{"pm.response.to.have.status(200);", "pm.response.to.be.ok;"}, get.Event[0].Script.Exec)
+
+	ping := c.Item[1]
+	require.Equal(t, "GET", ping.Request.Method)
+	require.Equal(t, "{{baseUrl}}/ping", ping.Request.URL.String())
+}
+
+func TestLoadCollectionUnsupportedSchema(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "collection.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"info": {"schema": "https://schema.getpostman.com/json/collection/v2.0.0/collection.json"}}`), 0600))
+
+	_, err := LoadCollection(path)
+	require.ErrorContains(t, err, "export collection as v2.1")
+}
+
+func TestVariables(t *testing.T) {
+	dir := t.TempDir()
+	collectionPath := filepath.Join(dir, "collection.json")
+	environmentPath := filepath.Join(dir, "environment.json")
+
+	require.NoError(t, os.WriteFile(collectionPath, []byte(collection), 0600))
+	require.NoError(t, os.WriteFile(environmentPath, []byte(environment), 0600))
+
+	c, err := LoadCollection(collectionPath)
+	require.NoError(t, err)
+
+	env, err := LoadEnvironment(environmentPath)
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]string{
+		"baseUrl": "https://dev.go.com",
+		"limit":   "10",
+		"token":   "secret",
+	}, Variables(c, env))
+}
@@ -40,3 +40,139 @@ func DrainBody(body io.ReadCloser) (r1, r2 io.ReadCloser, err error) {
 
 	return io.NopCloser(&buf), io.NopCloser(bytes.NewReader(buf.Bytes())), nil
 }
+
+// BufferedBody is a body, which was read once and keeps bytes for repeated access.
+// If body is larger than limit, only first limit bytes are buffered and body is marked as truncated.
+type BufferedBody struct {
+	data      []byte
+	reader    *bytes.Reader
+	truncated bool
+}
+
+// NewBufferedBody reads body once and closes it. If limit > 0, not more than limit bytes are buffered,
+// rest of body is not read.
+func NewBufferedBody(body io.ReadCloser, limit int64) (*BufferedBody, error) {
+	var (
+		data      []byte
+		err       error
+		truncated bool
+	)
+
+	if body == nil || body == http.NoBody {
+		return NewBufferedBodyFromBytes(nil), nil
+	}
+
+	if limit <= 0 {
+		data, err = io.ReadAll(body)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = body.Close(); err != nil {
+			return nil, err
+		}
+	} else {
+		data, err = io.ReadAll(io.LimitReader(body, limit+1))
+		if err != nil {
+			return nil, err
+		}
+
+		if int64(len(data)) > limit {
+			data = data[:limit]
+			truncated = true
+		}
+
+		// Body is closed after limited read too, so connection isn't kept by unread rest of body
+		if err = body.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	return &BufferedBody{
+		data:      data,
+		reader:    bytes.NewReader(data),
+		truncated: truncated,
+	}, nil
+}
+
+// NewBufferedBodyFromBytes creates BufferedBody without copy of data
+func NewBufferedBodyFromBytes(data []byte) *BufferedBody {
+	return &BufferedBody{
+		data:   data,
+		reader: bytes.NewReader(data),
+	}
+}
+
+// Read reads buffered bytes
+func (b *BufferedBody) Read(p []byte) (int, error) {
+	return b.reader.Read(p)
+}
+
+// Close does nothing, because source body is already closed
+func (b *BufferedBody) Close() error {
+	return nil
+}
+
+// Bytes returns buffered bytes regardless of read position
+func (b *BufferedBody) Bytes() []byte {
+	return b.data
+}
+
+// Truncated returns true, if body is larger than limit
+func (b *BufferedBody) Truncated() bool {
+	return b.truncated
+}
+
+// Rewind sets read position to the beginning of buffered bytes
+func (b *BufferedBody) Rewind() {
+	b.reader.Reset(b.data)
+}
+
+// NewReader returns new independent BufferedBody with the same bytes
+func (b *BufferedBody) NewReader() *BufferedBody {
+	return &BufferedBody{
+		data:      b.data,
+		reader:    bytes.NewReader(b.data),
+		truncated: b.truncated,
+	}
+}
+
+// StreamBody is a body, which is opened on first read.
+// It is used for send big payloads without buffering them in memory.
+type StreamBody struct {
+	open func() (io.ReadCloser, error)
+	rc   io.ReadCloser
+}
+
+// NewStreamBody creates StreamBody, open is called on first read
+func NewStreamBody(open func() (io.ReadCloser, error)) *StreamBody {
+	return &StreamBody{open: open}
+}
+
+// Read opens body, if it is needed, and reads it
+func (s *StreamBody) Read(p []byte) (int, error) {
+	if s.rc == nil {
+		rc, err := s.open()
+		if err != nil {
+			return 0, err
+		}
+
+		s.rc = rc
+	}
+
+	return s.rc.Read(p)
+}
+
+// Close closes body, if it was opened
+func (s *StreamBody) Close() error {
+	if s.rc == nil {
+		return nil
+	}
+
+	return s.rc.Close()
+}
+
+// Reopen returns new unread StreamBody with the same source
+func (s *StreamBody) Reopen() (io.ReadCloser, error) {
+	return NewStreamBody(s.open), nil
+}
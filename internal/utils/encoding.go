@@ -0,0 +1,156 @@
+package utils
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// ErrUnsupportedEncoding is returned, when content encoding is unknown
+var ErrUnsupportedEncoding = errors.New("unsupported content encoding")
+
+// EncodeBody compresses body with content encoding (gzip, deflate, zstd)
+func EncodeBody(encoding string, body []byte) ([]byte, error) {
+	buf := new(bytes.Buffer)
+
+	w, err := NewEncoder(encoding, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = w.Write(body); err != nil {
+		return nil, err
+	}
+
+	if err = w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// NewEncoder returns writer, which compresses data with content encoding and writes it to w.
+// Close of encoder does not close w.
+func NewEncoder(encoding string, w io.Writer) (io.WriteCloser, error) {
+	switch normalizeEncoding(encoding) {
+	case "", "identity":
+		return nopWriteCloser{w}, nil
+	case "gzip":
+		return gzip.NewWriter(w), nil
+	case "deflate":
+		return zlib.NewWriter(w), nil
+	case "zstd":
+		return zstd.NewWriter(w)
+	default:
+		return nil, fmt.Errorf("%w %v", ErrUnsupportedEncoding, encoding)
+	}
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// DecodeBody decompresses body by Content-Encoding header value.
+// Header may contain several encodings, they are decoded in reverse order.
+func DecodeBody(contentEncoding string, body []byte) ([]byte, error) {
+	decoded, _, err := DecodeBodyLimit(contentEncoding, body, 0)
+
+	return decoded, err
+}
+
+// DecodeBodyLimit decompresses body like DecodeBody, but not more than limit bytes, if limit > 0.
+// Second value is true, if decompressed body is larger than limit, then only first limit bytes are returned.
+func DecodeBodyLimit(contentEncoding string, body []byte, limit int64) ([]byte, bool, error) {
+	encodings := strings.Split(contentEncoding, ",")
+
+	for i := len(encodings) - 1; i >= 0; i-- {
+		var (
+			truncated bool
+			err       error
+		)
+
+		body, truncated, err = decode(encodings[i], body, limit)
+		if err != nil {
+			return nil, false, err
+		}
+
+		// Truncated body can't be decoded by next encoding
+		if truncated {
+			return body, true, nil
+		}
+	}
+
+	return body, false, nil
+}
+
+func decode(encoding string, body []byte, limit int64) ([]byte, bool, error) {
+	var (
+		r   io.Reader
+		err error
+	)
+
+	switch normalizeEncoding(encoding) {
+	case "", "identity":
+		return body, false, nil
+	case "gzip":
+		r, err = gzip.NewReader(bytes.NewReader(body))
+	case "deflate":
+		// RFC says deflate is zlib format, but some servers send raw deflate
+		r, err = zlib.NewReader(bytes.NewReader(body))
+		if err != nil {
+			r, err = flate.NewReader(bytes.NewReader(body)), nil
+		}
+	case "zstd":
+		var d *zstd.Decoder
+
+		d, err = zstd.NewReader(bytes.NewReader(body))
+		if err == nil {
+			defer d.Close()
+
+			r = d
+		}
+	default:
+		return nil, false, fmt.Errorf("%w %v", ErrUnsupportedEncoding, encoding)
+	}
+
+	if err != nil {
+		return nil, false, fmt.Errorf("could not decode %v body: %w", encoding, err)
+	}
+
+	// Small compressed body could expand without limit
+	if limit > 0 {
+		r = io.LimitReader(r, limit+1)
+	}
+
+	decoded, err := io.ReadAll(r)
+	if err != nil {
+		return nil, false, fmt.Errorf("could not decode %v body: %w", encoding, err)
+	}
+
+	if limit > 0 && int64(len(decoded)) > limit {
+		return decoded[:limit], true, nil
+	}
+
+	return decoded, false, nil
+}
+
+func normalizeEncoding(encoding string) string {
+	encoding = strings.ToLower(strings.TrimSpace(encoding))
+
+	if encoding == "x-gzip" {
+		return "gzip"
+	}
+
+	return encoding
+}
@@ -1,78 +1,340 @@
 package cute
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
 
-	"github.com/ozontech/cute/errors"
-	"github.com/xeipuuv/gojsonschema"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	cuteErrors "github.com/ozontech/cute/errors"
 )
 
+var jsonSchemaPrinter = message.NewPrinter(language.English)
+
 // Validate is a function to validate json by json schema.
 // Automatically add information about validation to allure.
 func (it *Test) validateJSONSchema(t internalT, body []byte) []error {
 	var (
-		expect gojsonschema.JSONLoader
+		compile  func() (*jsonschema.Schema, error)
+		registry = it.jsonSchemaRegistry
 	)
 
+	if registry == nil {
+		registry = defaultJSONSchemaRegistry()
+	}
+
 	switch {
 	case it.Expect.JSONSchema.String != "":
-		expect = gojsonschema.NewStringLoader(it.Expect.JSONSchema.String)
+		compile = func() (*jsonschema.Schema, error) {
+			return registry.compileInline([]byte(it.Expect.JSONSchema.String))
+		}
 	case it.Expect.JSONSchema.Byte != nil:
-		expect = gojsonschema.NewBytesLoader(it.Expect.JSONSchema.Byte)
+		compile = func() (*jsonschema.Schema, error) {
+			return registry.compileInline(it.Expect.JSONSchema.Byte)
+		}
 	case it.Expect.JSONSchema.File != "":
-		expect = gojsonschema.NewReferenceLoader(it.Expect.JSONSchema.File)
+		compile = func() (*jsonschema.Schema, error) {
+			return registry.compileFile(it.Expect.JSONSchema.File)
+		}
 	default:
 		return nil
 	}
 
 	return it.executeWithStep(t, "Validate body by JSON schema", func(_ T) []error {
+		expect, err := compile()
+		if err != nil {
+			return []error{cuteErrors.NewEmptyAssertError("could not validate json schema", err.Error())}
+		}
+
 		return checkJSONSchema(expect, body)
 	})
 }
 
-func checkJSONSchema(expect gojsonschema.JSONLoader, data []byte) []error {
-	scope := make([]error, 0)
-
-	validateResult, err := gojsonschema.Validate(expect, gojsonschema.NewBytesLoader(data))
+func checkJSONSchema(expect *jsonschema.Schema, data []byte) []error {
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(data))
 	if err != nil {
-		return []error{errors.NewEmptyAssertError("could not validate json schema", err.Error())}
+		return []error{cuteErrors.NewEmptyAssertError("could not validate json schema", err.Error())}
 	}
 
-	if !validateResult.Valid() && len(validateResult.Errors()) > 0 {
-		for _, resultError := range validateResult.Errors() {
-			scope = append(
-				scope,
-				createJSONSchemaError(resultError),
-			)
-		}
+	err = expect.Validate(instance)
+	if err == nil {
+		return nil
+	}
+
+	var validationError *jsonschema.ValidationError
+	if !errors.As(err, &validationError) {
+		return []error{cuteErrors.NewEmptyAssertError("could not validate json schema", err.Error())}
+	}
+
+	leaves := jsonSchemaLeafErrors(validationError)
+	scope := make([]error, 0, len(leaves))
+
+	for _, leaf := range leaves {
+		scope = append(scope, createJSONSchemaErrors(leaf, instance)...)
 	}
 
 	return scope
 }
 
-func createJSONSchemaError(err gojsonschema.ResultError) error {
-	fields := make(map[string]interface{})
-	textError := ""
+// jsonSchemaLeafErrors returns errors without causes, every leaf is a separate problem in body
+func jsonSchemaLeafErrors(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+
+	res := make([]*jsonschema.ValidationError, 0, len(err.Causes))
+
+	for _, cause := range err.Causes {
+		res = append(res, jsonSchemaLeafErrors(cause)...)
+	}
+
+	return res
+}
+
+// createJSONSchemaErrors returns assert errors in the same format, as errors of previous validator (gojsonschema),
+// so names and messages of errors are not changed for users
+func createJSONSchemaErrors(err *jsonschema.ValidationError, instance any) []error {
+	var (
+		path    = strings.Join(append([]string{"(root)"}, err.InstanceLocation...), ".")
+		field   = strings.TrimPrefix(path, "(root).")
+		message = err.ErrorKind.LocalizedString(jsonSchemaPrinter)
+		types   = jsonSchemaErrorTypes(err, field)
+		res     = make([]error, 0, len(types))
+	)
+
+	actual, expected := jsonSchemaErrorValues(err, instance, message)
+
+	for _, errorType := range types {
+		fields := map[string]interface{}{
+			"Path":  path,
+			"Field": field,
+		}
+
+		textError := fmt.Sprintf("On path: %v. Error field: %v. Error: %v: %v.", path, field, field, errorType.description)
+
+		errorExpected := expected
+		if _, isRequired := err.ErrorKind.(*kind.Required); isRequired {
+			errorExpected = errorType.property
+		}
+
+		assertError := cuteErrors.NewAssertError(
+			fmt.Sprintf("Error \"%v\"", errorType.name),
+			textError,
+			actual,
+			errorExpected)
 
-	if v, ok := err.Details()["context"]; ok {
-		textError = fmt.Sprintf("On path: %v.", v)
-		fields["Path"] = v
+		assertError.(cuteErrors.WithFields).PutFields(fields)
+
+		res = append(res, assertError)
 	}
 
-	if v, ok := err.Details()["field"]; ok {
-		textError = fmt.Sprintf("%v Error field: %v.", textError, v)
-		fields["Field"] = v
+	return res
+}
+
+// jsonSchemaErrorType is a type and a description of error of previous validator (gojsonschema)
+type jsonSchemaErrorType struct {
+	name        string
+	description string
+	// property is a property of error, for example missing required property
+	property string
+}
+
+// jsonSchemaErrorTypes maps kind of error to types of errors of previous validator
+// Missing required properties and additional properties are separate errors, as it was before.
+// Keywords, which were not supported before, are named by keyword path.
+func jsonSchemaErrorTypes(err *jsonschema.ValidationError, field string) []jsonSchemaErrorType {
+	single := func(name, format string, args ...interface{}) []jsonSchemaErrorType {
+		return []jsonSchemaErrorType{{name: name, description: fmt.Sprintf(format, args...)}}
 	}
 
-	textError = fmt.Sprintf("%v Error: %v.", textError, err.String())
+	each := func(name, format string, values []string) []jsonSchemaErrorType {
+		res := make([]jsonSchemaErrorType, 0, len(values))
 
-	assertError := errors.NewAssertError(
-		fmt.Sprintf("Error \"%v\"", err.Type()),
-		textError,
-		err.Details()["given"],
-		err.Details()["expected"])
+		for _, v := range values {
+			res = append(res, jsonSchemaErrorType{name: name, description: fmt.Sprintf(format, v), property: v})
+		}
 
-	assertError.(errors.WithFields).PutFields(fields)
+		return res
+	}
+
+	switch k := err.ErrorKind.(type) {
+	case *kind.Type:
+		return single("invalid_type", "Invalid type. Expected: %v, given: %v", jsonSchemaKindValue(k.Want), k.Got)
+	case *kind.Required:
+		return each("required", "%v is required", k.Missing)
+	case *kind.AdditionalProperties:
+		return each("additional_property_not_allowed", "Additional property %v is not allowed", k.Properties)
+	case *kind.Dependency:
+		return each("missing_dependency", "Has a dependency on %v", k.Missing)
+	case *kind.DependentRequired:
+		return each("missing_dependency", "Has a dependency on %v", k.Missing)
+	case *kind.Enum:
+		allowed := make([]string, 0, len(k.Want))
+		for _, v := range k.Want {
+			allowed = append(allowed, jsonSchemaJSONString(v))
+		}
+
+		return single("enum", "%v must be one of the following: %v", field, strings.Join(allowed, ", "))
+	case *kind.Const:
+		return single("const", "%v does not match: %v", field, jsonSchemaJSONString(k.Want))
+	case *kind.FalseSchema:
+		return single("false", "False always fails validation")
+	case *kind.AnyOf:
+		return single("number_any_of", "Must validate at least one schema (anyOf)")
+	case *kind.OneOf:
+		return single("number_one_of", "Must validate one and only one schema (oneOf)")
+	case *kind.AllOf:
+		return single("number_all_of", "Must validate all the schemas (allOf)")
+	case *kind.Not:
+		return single("number_not", "Must not validate the schema (not)")
+	case *kind.AdditionalItems:
+		return single("array_no_additional_items", "No additional items allowed on array")
+	case *kind.MinItems:
+		return single("array_min_items", "Array must have at least %v items", k.Want)
+	case *kind.MaxItems:
+		return single("array_max_items", "Array must have at most %v items", k.Want)
+	case *kind.UniqueItems:
+		return single("unique", "array items[%v,%v] must be unique", k.Duplicates[0], k.Duplicates[1])
+	case *kind.Contains, *kind.MinContains, *kind.MaxContains:
+		return single("contains", "At least one of the items must match")
+	case *kind.MinProperties:
+		return single("array_min_properties", "Must have at least %v properties", k.Want)
+	case *kind.MaxProperties:
+		return single("array_max_properties", "Must have at most %v properties", k.Want)
+	case *kind.PropertyNames:
+		return single("invalid_property_name", "Property name of \"%v\" does not match", k.Property)
+	case *kind.MinLength:
+		return single("string_gte", "String length must be greater than or equal to %v", k.Want)
+	case *kind.MaxLength:
+		return single("string_lte", "String length must be less than or equal to %v", k.Want)
+	case *kind.Pattern:
+		return single("pattern", "Does not match pattern '%v'", k.Want)
+	case *kind.Format:
+		return single("format", "Does not match format '%v'", k.Want)
+	case *kind.MultipleOf:
+		return single("multiple_of", "Must be a multiple of %v", jsonSchemaNumber(k.Want))
+	case *kind.Minimum:
+		return single("number_gte", "Must be greater than or equal to %v", jsonSchemaNumber(k.Want))
+	case *kind.ExclusiveMinimum:
+		return single("number_gt", "Must be greater than %v", jsonSchemaNumber(k.Want))
+	case *kind.Maximum:
+		return single("number_lte", "Must be less than or equal to %v", jsonSchemaNumber(k.Want))
+	case *kind.ExclusiveMaximum:
+		return single("number_lt", "Must be less than %v", jsonSchemaNumber(k.Want))
+	default:
+		return single(strings.Join(err.ErrorKind.KeywordPath(), "/"), "%v", err.ErrorKind.LocalizedString(jsonSchemaPrinter))
+	}
+}
+
+// jsonSchemaNumber returns number in format of previous validator
+func jsonSchemaNumber(v *big.Rat) string {
+	if v == nil {
+		return ""
+	}
+
+	return new(big.Float).SetRat(v).String()
+}
+
+// jsonSchemaJSONString returns value in JSON, as value of enum or const was shown before
+func jsonSchemaJSONString(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+
+	return string(data)
+}
+
+// jsonSchemaErrorValues returns actual and expected values of error
+// Most of error kinds have Got and Want fields, for other kinds actual value is taken from body.
+func jsonSchemaErrorValues(err *jsonschema.ValidationError, instance any, message string) (interface{}, interface{}) {
+	var (
+		actual   interface{}
+		expected interface{} = message
+	)
+
+	if v, ok := jsonPointerValue(instance, err.InstanceLocation); ok {
+		actual = v
+	}
+
+	kindValue := reflect.ValueOf(err.ErrorKind)
+	if kindValue.Kind() == reflect.Ptr {
+		kindValue = kindValue.Elem()
+	}
+
+	if kindValue.Kind() != reflect.Struct {
+		return actual, expected
+	}
+
+	if got := kindValue.FieldByName("Got"); got.IsValid() {
+		actual = jsonSchemaKindValue(got.Interface())
+	}
+
+	if want := kindValue.FieldByName("Want"); want.IsValid() {
+		expected = jsonSchemaKindValue(want.Interface())
+	}
+
+	if required, ok := err.ErrorKind.(*kind.Required); ok {
+		actual = nil
+		expected = strings.Join(required.Missing, ", ")
+	}
+
+	return actual, expected
+}
+
+func jsonSchemaKindValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case *big.Rat:
+		if value == nil {
+			return nil
+		}
+
+		f, _ := value.Float64()
+
+		return f
+	case []string:
+		// types are shown as [type1,type2], as it was before
+		if len(value) == 1 {
+			return value[0]
+		}
+
+		return fmt.Sprintf("[%v]", strings.Join(value, ","))
+	default:
+		return v
+	}
+}
+
+func jsonPointerValue(instance any, location []string) (any, bool) {
+	current := instance
+
+	for _, token := range location {
+		switch value := current.(type) {
+		case map[string]any:
+			next, ok := value[token]
+			if !ok {
+				return nil, false
+			}
+
+			current = next
+		case []any:
+			var index int
+			if _, err := fmt.Sscanf(token, "%d", &index); err != nil || index < 0 || index >= len(value) {
+				return nil, false
+			}
+
+			current = value[index]
+		default:
+			return nil, false
+		}
+	}
 
-	return assertError
+	return current, true
 }
@@ -0,0 +1,306 @@
+package cute
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+const jsonSchemaHTTPTimeout = 30 * time.Second
+
+var (
+	jsonSchemaRegistryCounter uint64
+
+	defaultRegistryOnce sync.Once
+	defaultRegistry     *JSONSchemaRegistry
+)
+
+// JSONSchemaRegistry is a set of JSON schemas from local directory or embed.FS.
+// Registry resolves $ref between schemas, both by relative path and by $id,
+// and caches compiled schemas, so every schema is compiled once for all tests.
+// Supported drafts are 4, 6, 7, 2019-09 and 2020-12. Draft is defined by $schema keyword, default draft is 7.
+// Registry is safe for concurrent use.
+type JSONSchemaRegistry struct {
+	fsys    fs.FS
+	baseURL string
+	// ids is a map of $id to path of schema in fsys
+	ids map[string]string
+
+	mu       sync.Mutex
+	compiler *jsonschema.Compiler
+	schemas  map[string]*jsonschema.Schema
+	inline   map[string]*jsonschema.Schema
+}
+
+// NewJSONSchemaRegistryFromDir is a function for create registry with all *.json schemas from directory
+func NewJSONSchemaRegistryFromDir(dir string) (*JSONSchemaRegistry, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not open schema directory %v error: '%s'", dir, err)
+	}
+
+	if !info.IsDir() {
+		return nil, fmt.Errorf("could not open schema directory %v error: 'not a directory'", dir)
+	}
+
+	return NewJSONSchemaRegistry(os.DirFS(dir))
+}
+
+// NewJSONSchemaRegistry is a function for create registry with all *.json schemas from file system, for example embed.FS
+// Schemas are referenced by path from root of file system, for example ExpectJSONSchemaFile("users/user.json").
+func NewJSONSchemaRegistry(fsys fs.FS) (*JSONSchemaRegistry, error) {
+	r := newJSONSchemaRegistry(fsys, fmt.Sprintf("cute-registry://%v/", atomic.AddUint64(&jsonSchemaRegistryCounter, 1)))
+
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || path.Ext(p) != ".json" {
+			return nil
+		}
+
+		doc, err := r.loadFile(p)
+		if err != nil {
+			return err
+		}
+
+		if obj, isObject := doc.(map[string]interface{}); isObject {
+			if id, hasID := obj["$id"].(string); hasID && id != "" {
+				r.ids[strings.TrimSuffix(id, "#")] = p
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not load schemas error: '%s'", err)
+	}
+
+	return r, nil
+}
+
+func newJSONSchemaRegistry(fsys fs.FS, baseURL string) *JSONSchemaRegistry {
+	r := &JSONSchemaRegistry{
+		fsys:    fsys,
+		baseURL: baseURL,
+		ids:     make(map[string]string),
+		schemas: make(map[string]*jsonschema.Schema),
+		inline:  make(map[string]*jsonschema.Schema),
+	}
+
+	r.compiler = r.newCompiler()
+
+	return r
+}
+
+// defaultJSONSchemaRegistry returns registry for tests without own registry
+// Relative paths are resolved from working directory.
+func defaultJSONSchemaRegistry() *JSONSchemaRegistry {
+	defaultRegistryOnce.Do(func() {
+		baseURL := "file:///"
+
+		if wd, err := os.Getwd(); err == nil {
+			baseURL = fileURL(wd) + "/"
+		}
+
+		defaultRegistry = newJSONSchemaRegistry(nil, baseURL)
+	})
+
+	return defaultRegistry
+}
+
+func (r *JSONSchemaRegistry) newCompiler() *jsonschema.Compiler {
+	c := jsonschema.NewCompiler()
+	c.DefaultDraft(jsonschema.Draft7)
+	c.AssertFormat()
+	c.UseLoader(jsonSchemaLoader{registry: r})
+
+	return c
+}
+
+// compileFile returns compiled schema by path or url, schema is compiled once
+func (r *JSONSchemaRegistry) compileFile(file string) (*jsonschema.Schema, error) {
+	url, err := r.resolve(file)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if sch, ok := r.schemas[url]; ok {
+		return sch, nil
+	}
+
+	sch, err := r.compiler.Compile(url)
+	if err != nil {
+		return nil, err
+	}
+
+	r.schemas[url] = sch
+
+	return sch, nil
+}
+
+// compileInline returns compiled schema from bytes, same schema is compiled once
+// Every inline schema has own compiler, because different schemas could have the same $id.
+func (r *JSONSchemaRegistry) compileInline(schema []byte) (*jsonschema.Schema, error) {
+	hash := sha256.Sum256(schema)
+	key := hex.EncodeToString(hash[:])
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if sch, ok := r.inline[key]; ok {
+		return sch, nil
+	}
+
+	doc, err := jsonschema.UnmarshalJSON(bytes.NewReader(schema))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse json schema error: '%s'", err)
+	}
+
+	// schema is placed in root of registry, so relative $ref is resolved from registry
+	url := r.baseURL + "inline-" + key + ".json"
+
+	c := r.newCompiler()
+
+	if err = c.AddResource(url, doc); err != nil {
+		return nil, err
+	}
+
+	sch, err := c.Compile(url)
+	if err != nil {
+		return nil, err
+	}
+
+	r.inline[key] = sch
+
+	return sch, nil
+}
+
+// resolve returns absolute url of schema
+// Path without scheme is resolved from root of registry.
+// Relative file url, like file://./schema.json, is resolved from working directory.
+func (r *JSONSchemaRegistry) resolve(file string) (string, error) {
+	if strings.HasPrefix(file, "file://") {
+		p := strings.TrimPrefix(file, "file://")
+		if filepath.IsAbs(p) {
+			return file, nil
+		}
+
+		abs, err := filepath.Abs(p)
+		if err != nil {
+			return "", fmt.Errorf("could not resolve json schema path %v error: '%s'", file, err)
+		}
+
+		return fileURL(abs), nil
+	}
+
+	if strings.Contains(file, "://") {
+		return file, nil
+	}
+
+	if r.fsys != nil {
+		return r.baseURL + path.Clean(filepath.ToSlash(file)), nil
+	}
+
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return "", fmt.Errorf("could not resolve json schema path %v error: '%s'", file, err)
+	}
+
+	return fileURL(abs), nil
+}
+
+func (r *JSONSchemaRegistry) loadFile(p string) (interface{}, error) {
+	f, err := r.fsys.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	doc, err := jsonschema.UnmarshalJSON(f)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse json schema %v error: '%s'", p, err)
+	}
+
+	return doc, nil
+}
+
+// jsonSchemaLoader loads schemas from registry, local files and http
+type jsonSchemaLoader struct {
+	registry *JSONSchemaRegistry
+}
+
+func (l jsonSchemaLoader) Load(url string) (any, error) {
+	r := l.registry
+
+	if r.fsys != nil {
+		if p, ok := strings.CutPrefix(url, r.baseURL); ok {
+			return r.loadFile(p)
+		}
+
+		if p, ok := r.ids[url]; ok {
+			return r.loadFile(p)
+		}
+	}
+
+	u, err := neturl.Parse(url)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "file":
+		return jsonschema.FileLoader{}.Load(url)
+	case "http", "https":
+		return loadJSONSchemaHTTP(url)
+	default:
+		return nil, fmt.Errorf("could not load json schema %v: schema is not found", url)
+	}
+}
+
+func loadJSONSchemaHTTP(url string) (any, error) {
+	client := &http.Client{Timeout: jsonSchemaHTTPTimeout}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not load json schema %v: status %v", url, resp.StatusCode)
+	}
+
+	return jsonschema.UnmarshalJSON(resp.Body)
+}
+
+func fileURL(p string) string {
+	p = filepath.ToSlash(p)
+	if !strings.HasPrefix(p, "/") {
+		p = "/" + p
+	}
+
+	return "file://" + p
+}
@@ -1,7 +1,10 @@
 package cute
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	cuteErrors "github.com/ozontech/cute/errors"
 	"github.com/stretchr/testify/require"
@@ -157,3 +160,177 @@ func TestValidateJSONSchemaFromByteWithTwoError(t *testing.T) {
 		require.NotEmpty(t, expectedError.GetFields()["Expected"])
 	}
 }
+
+func TestValidateJSONSchemaErrorFormat(t *testing.T) {
+	schema := []byte(`
+	{
+	  "$schema": "http://json-schema.org/draft-07/schema#",
+	  "type": "object",
+	  "required": ["id", "name"],
+	  "properties": {
+	    "id": {"type": "integer"},
+	    "name": {"type": "string"},
+	    "age": {"type": "integer", "minimum": 18},
+	    "role": {"enum": ["admin", "user"]},
+	    "tags": {"type": ["array", "null"]}
+	  },
+	  "additionalProperties": false
+	}`)
+
+	registry := newJSONSchemaRegistry(nil, "file:///")
+
+	expect, err := registry.compileInline(schema)
+	require.NoError(t, err)
+
+	errs := checkJSONSchema(expect, []byte(`{"age": 10, "role": "guest", "tags": "a", "extra": 1}`))
+
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.(cuteErrors.WithNameError).GetName()+" "+err.Error())
+	}
+
+	require.ElementsMatch(t, []string{
+		`Error "required" On path: (root). Error field: (root). Error: (root): id is required.`,
+		`Error "required" On path: (root). Error field: (root). Error: (root): name is required.`,
+		`Error "additional_property_not_allowed" On path: (root). Error field: (root). Error: (root): Additional property extra is not allowed.`,
+		`Error "number_gte" On path: (root).age. Error field: age. Error: age: Must be greater than or equal to 18.`,
+		`Error "enum" On path: (root).role. Error field: role. Error: role: role must be one of the following: "admin", "user".`,
+		`Error "invalid_type" On path: (root).tags. Error field: tags. Error: tags: Invalid type. Expected: [null,array], given: string.`,
+	}, messages)
+
+	errs = checkJSONSchema(expect, []byte(`{"id": 1, "name": "cute", "age": "10"}`))
+	require.Len(t, errs, 1)
+	require.Equal(t, `Error "invalid_type"`, errs[0].(cuteErrors.WithNameError).GetName())
+	require.Equal(t, "On path: (root).age. Error field: age. Error: age: Invalid type. Expected: integer, given: string.", errs[0].Error())
+}
+
+func TestValidateJSONSchemaDraft2020(t *testing.T) {
+	var (
+		tBuilder = createDefaultTest(&HTTPTestMaker{middleware: new(Middleware)})
+		tempT    = createAllureT(t)
+	)
+
+	tBuilder.initEmptyFields()
+
+	tBuilder.Expect.JSONSchema.String = `
+	{
+	  "$schema": "https://json-schema.org/draft/2020-12/schema",
+	  "type": "object",
+	  "properties": {
+	    "point": {
+	      "type": "array",
+	      "prefixItems": [{"type": "number"}, {"type": "number"}],
+	      "items": false
+	    }
+	  },
+	  "unevaluatedProperties": false
+	}
+	`
+
+	errs := tBuilder.validateJSONSchema(tempT, []byte(`{"point": [1, 2]}`))
+	require.Len(t, errs, 0)
+
+	errs = tBuilder.validateJSONSchema(tempT, []byte(`{"point": [1, 2, 3]}`))
+	require.Len(t, errs, 1)
+
+	errs = tBuilder.validateJSONSchema(tempT, []byte(`{"point": [1, 2], "extra": true}`))
+	require.Len(t, errs, 1)
+}
+
+func TestJSONSchemaRegistryFromDir(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "common"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "common", "defs.json"), []byte(`
+	{
+	  "$schema": "https://json-schema.org/draft/2019-09/schema",
+	  "$defs": {
+	    "id": {"type": "integer", "minimum": 1}
+	  }
+	}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "name.json"), []byte(`
+	{
+	  "$id": "https://example.com/schemas/name.json",
+	  "type": "string",
+	  "minLength": 1
+	}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "user.json"), []byte(`
+	{
+	  "$schema": "https://json-schema.org/draft/2020-12/schema",
+	  "type": "object",
+	  "required": ["id", "name"],
+	  "properties": {
+	    "id": {"$ref": "common/defs.json#/$defs/id"},
+	    "name": {"$ref": "https://example.com/schemas/name.json"}
+	  }
+	}`), 0600))
+
+	registry, err := NewJSONSchemaRegistryFromDir(dir)
+	require.NoError(t, err)
+
+	var (
+		tBuilder = createDefaultTest(&HTTPTestMaker{middleware: new(Middleware), jsonSchemaRegistry: registry})
+		tempT    = createAllureT(t)
+	)
+
+	tBuilder.initEmptyFields()
+	tBuilder.Expect.JSONSchema.File = "user.json"
+
+	errs := tBuilder.validateJSONSchema(tempT, []byte(`{"id": 1, "name": "cute"}`))
+	require.Len(t, errs, 0)
+
+	errs = tBuilder.validateJSONSchema(tempT, []byte(`{"id": 0, "name": ""}`))
+	require.Len(t, errs, 2)
+
+	errs = tBuilder.validateJSONSchema(tempT, []byte(`{"id": 1}`))
+	require.Len(t, errs, 1)
+	require.Equal(t, "(root)", errs[0].(cuteErrors.WithFields).GetFields()["Field"])
+	require.Equal(t, "name", errs[0].(cuteErrors.WithFields).GetFields()["Expected"])
+
+	// inline schema resolves relative $ref from registry
+	tBuilder.Expect.JSONSchema.File = ""
+	tBuilder.Expect.JSONSchema.String = `{"$ref": "common/defs.json#/$defs/id"}`
+
+	errs = tBuilder.validateJSONSchema(tempT, []byte(`-1`))
+	require.Len(t, errs, 1)
+}
+
+func TestJSONSchemaRegistryFromFS(t *testing.T) {
+	registry, err := NewJSONSchemaRegistry(fstest.MapFS{
+		"item.json":  {Data: []byte(`{"type": "object", "properties": {"price": {"$ref": "price.json"}}}`)},
+		"price.json": {Data: []byte(`{"type": "number", "exclusiveMinimum": 0}`)},
+	})
+	require.NoError(t, err)
+
+	first, err := registry.compileFile("item.json")
+	require.NoError(t, err)
+
+	errs := checkJSONSchema(first, []byte(`{"price": 0}`))
+	require.Len(t, errs, 1)
+
+	expectedError := errs[0].(cuteErrors.WithFields)
+	require.Equal(t, "(root).price", expectedError.GetFields()["Path"])
+	require.Equal(t, float64(0), expectedError.GetFields()["Actual"])
+
+	// schema is compiled once
+	second, err := registry.compileFile("item.json")
+	require.NoError(t, err)
+	require.Same(t, first, second)
+
+	_, err = registry.compileFile("not_found.json")
+	require.Error(t, err)
+}
+
+func TestJSONSchemaRegistryInlineCache(t *testing.T) {
+	registry := newJSONSchemaRegistry(nil, "file:///")
+
+	first, err := registry.compileInline([]byte(`{"type": "string"}`))
+	require.NoError(t, err)
+
+	second, err := registry.compileInline([]byte(`{"type": "string"}`))
+	require.NoError(t, err)
+	require.Same(t, first, second)
+
+	_, err = registry.compileInline([]byte(`{not_valid_json}`))
+	require.Error(t, err)
+}
@@ -0,0 +1,444 @@
+package cute
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const jsonSchemaTagName = "jsonschema"
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// JSONSchemaOption is an option for generate JSON schema from type
+type JSONSchemaOption func(*jsonSchemaOptions)
+
+type jsonSchemaOptions struct {
+	strict bool
+}
+
+// StrictJSONSchema is an option for reject fields, which are not declared in struct
+func StrictJSONSchema() JSONSchemaOption {
+	return func(o *jsonSchemaOptions) {
+		o.strict = true
+	}
+}
+
+// ExpectJSONSchemaFromType is a function for validate response by JSON schema, which is generated from type V.
+// Schema is generated by JSONSchemaFromType and set as ExpectJSONSchemaByte,
+// so body is validated and reported as any other schema.
+// Example:
+//
+//	cute.ExpectJSONSchemaFromType[Response](
+//		cute.NewTestBuilder().
+//			Title("Get user").
+//			Create().
+//			RequestBuilder(cute.WithURI("http://localhost/user/1")),
+//		cute.StrictJSONSchema(),
+//	).ExecuteTest(ctx, t)
+func ExpectJSONSchemaFromType[V any](builder ExpectHTTPBuilder, opts ...JSONSchemaOption) ExpectHTTPBuilder {
+	return builder.ExpectJSONSchemaByte(JSONSchemaFromType[V](opts...))
+}
+
+// JSONSchemaFromType is a function for generate JSON schema (draft 2020-12) from type V.
+// Schema could be used in table tests, for example as Expect.JSONSchema.Byte.
+// Example:
+//
+//	ExpectJSONSchemaByte(cute.JSONSchemaFromType[Response](cute.StrictJSONSchema()))
+//
+// Schema is generated by rules of encoding/json:
+// - property name is taken from json tag, fields with json:"-" and unexported fields are skipped
+// - fields without omitempty are required
+// - pointers, slices and maps could be null
+// - time.Time is a string in date-time format, types with MarshalJSON could be any value
+//
+// Additional rules are set by jsonschema tag, values are separated by comma:
+// - required, optional - override omitempty rule
+// - nullable - value could be null
+// - enum=a|b|c - value is one of list
+// - format=email - string format, for example date-time, email, uuid, uri
+// - minimum=1, maximum=10 - number limits
+// - minLength=1, maxLength=10 - string length limits
+//
+// Function panics, if type could not be presented in JSON, for example channel or function.
+func JSONSchemaFromType[V any](opts ...JSONSchemaOption) []byte {
+	o := &jsonSchemaOptions{}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	g := &jsonSchemaGenerator{
+		options: o,
+		defs:    make(map[string]interface{}),
+		names:   make(map[reflect.Type]string),
+	}
+
+	schema, err := g.schema(reflect.TypeOf((*V)(nil)).Elem())
+	if err != nil {
+		panic(fmt.Sprintf("could not generate json schema error: '%s'", err))
+	}
+
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+
+	if len(g.defs) != 0 {
+		schema["$defs"] = g.defs
+	}
+
+	res, err := json.Marshal(schema)
+	if err != nil {
+		panic(fmt.Sprintf("could not marshal json schema error: '%s'", err))
+	}
+
+	return res
+}
+
+type jsonSchemaGenerator struct {
+	options *jsonSchemaOptions
+	// defs contains schemas of named structs, so recursive types are supported
+	defs  map[string]interface{}
+	names map[reflect.Type]string
+}
+
+func (g *jsonSchemaGenerator) schema(t reflect.Type) (map[string]interface{}, error) {
+	if t.Kind() != reflect.Ptr && t.Kind() != reflect.Interface {
+		switch {
+		case t == timeType:
+			return map[string]interface{}{"type": "string", "format": "date-time"}, nil
+		case t == rawMessageType:
+			return map[string]interface{}{}, nil
+		case t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType):
+			return map[string]interface{}{}, nil
+		case t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType):
+			return map[string]interface{}{"type": "string"}, nil
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return map[string]interface{}{"type": "integer", "minimum": 0}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}, nil
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}, nil
+	case reflect.Interface:
+		return map[string]interface{}{}, nil
+	case reflect.Ptr:
+		elem, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		return nullable(elem), nil
+	case reflect.Slice, reflect.Array:
+		// []byte is encoded to base64 string
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return nullable(map[string]interface{}{"type": "string", "contentEncoding": "base64"}), nil
+		}
+
+		items, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		res := map[string]interface{}{"type": "array", "items": items}
+
+		if t.Kind() == reflect.Array {
+			res["minItems"] = t.Len()
+			res["maxItems"] = t.Len()
+
+			return res, nil
+		}
+
+		return nullable(res), nil
+	case reflect.Map:
+		values, err := g.schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+
+		return nullable(map[string]interface{}{"type": "object", "additionalProperties": values}), nil
+	case reflect.Struct:
+		return g.structRef(t)
+	default:
+		return nil, fmt.Errorf("type %v is not supported by JSON", t)
+	}
+}
+
+// structRef returns reference to schema of named struct, anonymous struct is returned as is
+func (g *jsonSchemaGenerator) structRef(t reflect.Type) (map[string]interface{}, error) {
+	if t.Name() == "" {
+		return g.structSchema(t)
+	}
+
+	name, ok := g.names[t]
+	if !ok {
+		name = t.Name()
+
+		for i := 2; g.defs[name] != nil; i++ {
+			name = fmt.Sprintf("%v%v", t.Name(), i)
+		}
+
+		g.names[t] = name
+		// placeholder for recursive types
+		g.defs[name] = map[string]interface{}{}
+
+		schema, err := g.structSchema(t)
+		if err != nil {
+			return nil, err
+		}
+
+		g.defs[name] = schema
+	}
+
+	return map[string]interface{}{"$ref": "#/$defs/" + name}, nil
+}
+
+func (g *jsonSchemaGenerator) structSchema(t reflect.Type) (map[string]interface{}, error) {
+	var (
+		properties = make(map[string]interface{})
+		required   = make([]string, 0)
+	)
+
+	if err := g.addFields(t, properties, &required); err != nil {
+		return nil, err
+	}
+
+	res := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+
+	if len(required) != 0 {
+		res["required"] = required
+	}
+
+	if g.options.strict {
+		res["additionalProperties"] = false
+	}
+
+	return res, nil
+}
+
+func (g *jsonSchemaGenerator) addFields(t reflect.Type, properties map[string]interface{}, required *[]string) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts := parseTag(tag)
+
+		fieldType := field.Type
+
+		// fields of embedded struct without name are fields of parent struct
+		if field.Anonymous && name == "" {
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+
+			if fieldType.Kind() == reflect.Struct {
+				if err := g.addFields(fieldType, properties, required); err != nil {
+					return err
+				}
+
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		schema, err := g.fieldSchema(field, opts)
+		if err != nil {
+			return fmt.Errorf("field %v: %w", field.Name, err)
+		}
+
+		isRequired := !strings.Contains(","+opts+",", ",omitempty,")
+
+		for _, rule := range strings.Split(field.Tag.Get(jsonSchemaTagName), ",") {
+			switch strings.TrimSpace(rule) {
+			case "required":
+				isRequired = true
+			case "optional":
+				isRequired = false
+			}
+		}
+
+		properties[name] = schema
+
+		if isRequired {
+			*required = append(*required, name)
+		}
+	}
+
+	return nil
+}
+
+func (g *jsonSchemaGenerator) fieldSchema(field reflect.StructField, jsonOpts string) (map[string]interface{}, error) {
+	// json:",string" encodes numbers and bools as string
+	if strings.Contains(","+jsonOpts+",", ",string,") {
+		switch field.Type.Kind() {
+		case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+			return map[string]interface{}{"type": "string"}, nil
+		}
+	}
+
+	schema, err := g.schema(field.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	tag := field.Tag.Get(jsonSchemaTagName)
+	if tag == "" {
+		return schema, nil
+	}
+
+	// rules are applied to value, but reference has to stay unchanged
+	if _, ok := schema["$ref"]; ok {
+		schema = map[string]interface{}{"allOf": []interface{}{schema}}
+	}
+
+	baseType := field.Type
+	for baseType.Kind() == reflect.Ptr {
+		baseType = baseType.Elem()
+	}
+
+	for _, rule := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(rule), "=")
+
+		switch key {
+		case "", "required", "optional":
+		case "nullable":
+			schema = nullable(schema)
+		case "format":
+			schema["format"] = value
+		case "enum":
+			enum, err := enumValues(baseType, value)
+			if err != nil {
+				return nil, err
+			}
+
+			schema["enum"] = enum
+		case "minimum", "maximum":
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse %v=%v", key, value)
+			}
+
+			schema[key] = number
+		case "minLength", "maxLength":
+			length, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse %v=%v", key, value)
+			}
+
+			schema[key] = length
+		default:
+			return nil, fmt.Errorf("unknown jsonschema rule %v", key)
+		}
+	}
+
+	// null has to be allowed by enum of nullable value
+	if enum, ok := schema["enum"].([]interface{}); ok && allowsNull(schema) {
+		schema["enum"] = append(enum, nil)
+	}
+
+	return schema, nil
+}
+
+func enumValues(t reflect.Type, value string) ([]interface{}, error) {
+	var (
+		items = strings.Split(value, "|")
+		res   = make([]interface{}, 0, len(items))
+	)
+
+	for _, item := range items {
+		switch t.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			number, err := strconv.ParseFloat(item, 64)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse enum value %v", item)
+			}
+
+			res = append(res, number)
+		case reflect.Bool:
+			b, err := strconv.ParseBool(item)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse enum value %v", item)
+			}
+
+			res = append(res, b)
+		default:
+			res = append(res, item)
+		}
+	}
+
+	return res, nil
+}
+
+// nullable allows null value for schema
+func nullable(schema map[string]interface{}) map[string]interface{} {
+	switch typ := schema["type"].(type) {
+	case string:
+		schema["type"] = []interface{}{typ, "null"}
+
+		return schema
+	case []interface{}:
+		if !allowsNull(schema) {
+			schema["type"] = append(typ, "null")
+		}
+
+		return schema
+	}
+
+	// schema without type (any value or reference)
+	if len(schema) == 0 {
+		return schema
+	}
+
+	return map[string]interface{}{
+		"anyOf": []interface{}{schema, map[string]interface{}{"type": "null"}},
+	}
+}
+
+func allowsNull(schema map[string]interface{}) bool {
+	if typ, ok := schema["type"].([]interface{}); ok {
+		for _, v := range typ {
+			if v == "null" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func parseTag(tag string) (string, string) {
+	name, opts, _ := strings.Cut(tag, ",")
+
+	return name, opts
+}
@@ -0,0 +1,161 @@
+package cute
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+
+	cuteErrors "github.com/ozontech/cute/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaBase struct {
+	ID int64 `json:"id"`
+}
+
+type schemaUser struct {
+	schemaBase
+
+	Name      string            `json:"name" jsonschema:"minLength=1"`
+	Email     string            `json:"email" jsonschema:"format=email"`
+	Role      string            `json:"role" jsonschema:"enum=admin|user"`
+	Age       *uint8            `json:"age,omitempty" jsonschema:"maximum=150"`
+	Tags      []string          `json:"tags"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	Manager   *schemaUser       `json:"manager,omitempty"`
+	Nickname  string            `json:"nickname,omitempty" jsonschema:"required"`
+	Secret    string            `json:"-"`
+	internal  string
+}
+
+func TestJSONSchemaFromType(t *testing.T) {
+	schema := JSONSchemaFromType[schemaUser]()
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(schema, &doc))
+
+	defs := doc["$defs"].(map[string]interface{})
+	user := defs["schemaUser"].(map[string]interface{})
+	properties := user["properties"].(map[string]interface{})
+
+	require.Equal(t, "#/$defs/schemaUser", doc["$ref"])
+	require.ElementsMatch(t, []interface{}{"id", "name", "email", "role", "tags", "created_at", "nickname"}, user["required"])
+	require.Contains(t, properties, "id")
+	require.NotContains(t, properties, "Secret")
+	require.NotContains(t, properties, "internal")
+	require.Nil(t, user["additionalProperties"])
+	require.Equal(t, map[string]interface{}{"type": "string", "format": "date-time"}, properties["created_at"])
+	require.Equal(t, []interface{}{"integer", "null"}, properties["age"].(map[string]interface{})["type"])
+}
+
+func TestJSONSchemaFromTypeValidate(t *testing.T) {
+	var (
+		tBuilder = createDefaultTest(&HTTPTestMaker{middleware: new(Middleware)})
+		tempT    = createAllureT(t)
+	)
+
+	tBuilder.initEmptyFields()
+	tBuilder.Expect.JSONSchema.Byte = JSONSchemaFromType[schemaUser]()
+
+	valid := []byte(`{
+		"id": 1,
+		"name": "cute",
+		"email": "cute@example.com",
+		"role": "admin",
+		"tags": null,
+		"created_at": "2024-01-01T10:00:00Z",
+		"nickname": "",
+		"manager": {"id": 2, "name": "boss", "email": "boss@example.com", "role": "user", "tags": [], "created_at": "2024-01-01T10:00:00Z", "nickname": "b"},
+		"extra": true
+	}`)
+
+	errs := tBuilder.validateJSONSchema(tempT, valid)
+	require.Len(t, errs, 0)
+
+	invalid := []byte(`{
+		"id": 1,
+		"name": "",
+		"email": "not email",
+		"role": "guest",
+		"age": 200,
+		"tags": [],
+		"created_at": "yesterday"
+	}`)
+
+	errs = tBuilder.validateJSONSchema(tempT, invalid)
+	require.Len(t, errs, 6)
+
+	for _, err := range errs {
+		require.NotEmpty(t, err.(cuteErrors.WithNameError).GetName())
+		require.NotEmpty(t, err.(cuteErrors.WithFields).GetFields()["Path"])
+	}
+}
+
+func TestJSONSchemaFromTypeStrict(t *testing.T) {
+	var (
+		tBuilder = createDefaultTest(&HTTPTestMaker{middleware: new(Middleware)})
+		tempT    = createAllureT(t)
+	)
+
+	type item struct {
+		Name  string `json:"name"`
+		Count int    `json:"count,string"`
+	}
+
+	tBuilder.initEmptyFields()
+	tBuilder.Expect.JSONSchema.Byte = JSONSchemaFromType[[]item](StrictJSONSchema())
+
+	errs := tBuilder.validateJSONSchema(tempT, []byte(`[{"name": "a", "count": "1"}]`))
+	require.Len(t, errs, 0)
+
+	errs = tBuilder.validateJSONSchema(tempT, []byte(`[{"name": "a", "count": "1", "extra": 1}]`))
+	require.Len(t, errs, 1)
+	require.Equal(t, "(root).0", errs[0].(cuteErrors.WithFields).GetFields()["Path"])
+}
+
+func TestJSONSchemaFromTypeUnsupported(t *testing.T) {
+	require.Panics(t, func() {
+		JSONSchemaFromType[struct {
+			C chan int `json:"c"`
+		}]()
+	})
+
+	require.Panics(t, func() {
+		JSONSchemaFromType[struct {
+			A string `json:"a" jsonschema:"unknown=1"`
+		}]()
+	})
+}
+
+func TestExpectJSONSchemaFromType(t *testing.T) {
+	type item struct {
+		ID int64 `json:"id" jsonschema:"minimum=1"`
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":` + r.URL.Query().Get("id") + `}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	for id, expectedFailed := range map[string]bool{"1": false, "0": true} {
+		builder := ExpectJSONSchemaFromType[item](
+			NewHTTPTestMaker().NewTestBuilder().
+				Title("schema").
+				Create().
+				RequestBuilder(WithURI(srv.URL), WithQueryKV("id", id)),
+			StrictJSONSchema(),
+		)
+
+		result, failed, _ := executeRecorded(t, builder)
+		require.Equal(t, expectedFailed, failed)
+
+		if expectedFailed {
+			require.Equal(t, allure.Failed, result.Status)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package cute
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// limiter limits rate and count of in-flight requests per host
+// Limiter is shared by all tests of HTTPTestMaker.
+type limiter struct {
+	rate     float64
+	burst    int
+	inFlight int
+
+	mu    sync.Mutex
+	hosts map[string]*hostLimiter
+}
+
+type hostLimiter struct {
+	// tokens and last are state of token bucket
+	tokens float64
+	last   time.Time
+	// slots is a semaphore of in-flight requests, nil means without limit
+	slots chan struct{}
+}
+
+// newLimiter returns nil, if limits are not set
+func newLimiter(rate float64, burst, inFlight int) *limiter {
+	if rate <= 0 && inFlight <= 0 {
+		return nil
+	}
+
+	if burst <= 0 {
+		burst = 1
+	}
+
+	return &limiter{
+		rate:     rate,
+		burst:    burst,
+		inFlight: inFlight,
+		hosts:    make(map[string]*hostLimiter),
+	}
+}
+
+func (l *limiter) host(host string) *hostLimiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	h, ok := l.hosts[host]
+	if !ok {
+		h = &hostLimiter{tokens: float64(l.burst), last: time.Now()}
+
+		if l.inFlight > 0 {
+			h.slots = make(chan struct{}, l.inFlight)
+		}
+
+		l.hosts[host] = h
+	}
+
+	return h
+}
+
+// reserve takes token of host and returns time, which request has to wait for it
+func (l *limiter) reserve(h *hostLimiter) time.Duration {
+	if l.rate <= 0 {
+		return 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+
+	if now.After(h.last) {
+		h.tokens = math.Min(float64(l.burst), h.tokens+now.Sub(h.last).Seconds()*l.rate)
+		h.last = now
+	}
+
+	// Tokens could be negative, it's a queue of requests, which reserved future tokens
+	h.tokens--
+
+	if h.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-h.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns unused token, if request was canceled while waiting
+func (l *limiter) cancel(h *hostLimiter) {
+	if l.rate <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	h.tokens++
+	l.mu.Unlock()
+}
+
+// wait blocks until request to host is allowed by limits
+// It returns function, which must be called after request, and time of waiting, it's 0 if request wasn't blocked.
+func (l *limiter) wait(ctx context.Context, host string) (func(), time.Duration, error) {
+	if l == nil {
+		return func() {}, 0, nil
+	}
+
+	var (
+		start   = time.Now()
+		blocked = false
+		h       = l.host(host)
+	)
+
+	waited := func() time.Duration {
+		if !blocked {
+			return 0
+		}
+
+		return time.Since(start)
+	}
+
+	if h.slots != nil {
+		select {
+		case h.slots <- struct{}{}:
+		default:
+			blocked = true
+
+			select {
+			case h.slots <- struct{}{}:
+			case <-ctx.Done():
+				return nil, waited(), ctx.Err()
+			}
+		}
+	}
+
+	release := func() {
+		if h.slots != nil {
+			<-h.slots
+		}
+	}
+
+	if delay := l.reserve(h); delay > 0 {
+		blocked = true
+
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			l.cancel(h)
+			release()
+
+			return nil, waited(), ctx.Err()
+		}
+	}
+
+	return release, waited(), nil
+}
@@ -0,0 +1,128 @@
+package cute
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLimiter(t *testing.T) {
+	require.Nil(t, newLimiter(0, 10, 0))
+
+	l := newLimiter(10, 0, 0)
+	require.Equal(t, 1, l.burst)
+}
+
+func TestLimiterRate(t *testing.T) {
+	l := newLimiter(10, 2, 0)
+
+	for i := 0; i < 2; i++ {
+		release, waited, err := l.wait(context.Background(), "go.com")
+		require.NoError(t, err)
+		require.Zero(t, waited)
+
+		release()
+	}
+
+	release, waited, err := l.wait(context.Background(), "go.com")
+	require.NoError(t, err)
+	require.Positive(t, waited)
+
+	release()
+
+	// Other host has own bucket
+	_, waited, err = l.wait(context.Background(), "other.go.com")
+	require.NoError(t, err)
+	require.Zero(t, waited)
+}
+
+func TestLimiterCanceled(t *testing.T) {
+	l := newLimiter(0, 0, 1)
+
+	release, _, err := l.wait(context.Background(), "go.com")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, waited, err := l.wait(ctx, "go.com")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.NotZero(t, waited)
+
+	release()
+
+	release, waited, err = l.wait(context.Background(), "go.com")
+	require.NoError(t, err)
+	require.Zero(t, waited)
+
+	release()
+}
+
+func TestMaxInFlightPerHost(t *testing.T) {
+	var (
+		current, peak, failures atomic.Int32
+		wg                      sync.WaitGroup
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := current.Add(1)
+		defer current.Add(-1)
+
+		for {
+			old := peak.Load()
+			if n <= old || peak.CompareAndSwap(old, n) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}))
+	t.Cleanup(srv.Close)
+
+	maker := NewHTTPTestMaker(WithMaxInFlightPerHost(2))
+
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			builder := maker.NewTestBuilder().
+				Title("limit").
+				Create().
+				RequestBuilder(WithURI(srv.URL), WithMethod(http.MethodGet)).
+				ExpectStatus(http.StatusOK)
+
+			if _, failed, _ := executeRecorded(t, builder); failed {
+				failures.Add(1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	require.Zero(t, failures.Load())
+	require.LessOrEqual(t, peak.Load(), int32(2))
+}
+
+func TestMaxInFlightPerHostTableTest(t *testing.T) {
+	maker := NewHTTPTestMaker(WithMaxInFlightPerHost(2))
+
+	tests := []*Test{
+		{Request: &Request{Builders: []RequestBuilder{WithURI("http://go.com/1")}}},
+		{Request: &Request{Builders: []RequestBuilder{WithURI("http://go.com/2")}}},
+	}
+
+	maker.NewTestBuilder().CreateTableTest().PutTests(tests...)
+
+	for _, test := range tests {
+		require.NotNil(t, test.limiter)
+		require.Same(t, maker.limiter, test.limiter)
+	}
+}
@@ -31,11 +31,14 @@ func (it *Test) logf(t tlogger, level, format string, args ...interface{}) {
 	if it.Name == "" {
 		name = t.Name()
 	}
+	// Sensitive data is hidden in all messages
+	message := it.redactor.String(fmt.Sprintf(format, args...))
+
 	// If we are in a retry context, add some indication in the logs about the current attempt
 	if it.Retry.MaxAttempts != 1 {
-		t.Logf("[%s][%s](Attempt #%d) %v\n", name, level, it.Retry.currentCount, fmt.Sprintf(format, args...))
+		t.Logf("[%s][%s](Attempt #%d) %v\n", name, level, it.Retry.currentCount, message)
 	} else {
-		t.Logf("[%s][%s] %v\n", name, level, fmt.Sprintf(format, args...))
+		t.Logf("[%s][%s] %v\n", name, level, message)
 	}
 }
 
@@ -45,10 +48,13 @@ func (it *Test) errorf(t tlogger, level, format string, args ...interface{}) {
 	if it.Name == "" {
 		name = t.Name()
 	}
+	// Sensitive data is hidden in all messages
+	message := it.redactor.String(fmt.Sprintf(format, args...))
+
 	// If we are in a retry context, add some indication in the logs about the current attempt
 	if it.Retry.MaxAttempts != 1 {
-		t.Logf("[%s][%s](Attempt #%d) %v\n", name, level, it.Retry.currentCount, fmt.Sprintf(format, args...))
+		t.Logf("[%s][%s](Attempt #%d) %v\n", name, level, it.Retry.currentCount, message)
 	} else {
-		t.Logf("[%s][%s] %v\n", name, level, fmt.Sprintf(format, args...))
+		t.Logf("[%s][%s] %v\n", name, level, message)
 	}
 }
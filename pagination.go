@@ -0,0 +1,368 @@
+package cute
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ohler55/ojg/jp"
+	"github.com/ohler55/ojg/oj"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+
+	cuteErrors "github.com/ozontech/cute/errors"
+)
+
+const defaultMaxPages = 100
+
+// AssertItems is type for create custom assertions for items of all pages, see RequestPagination
+// Items are parsed JSON values in order of pages.
+// Example asserts:
+// - total count of items
+// - ids of items are unique
+// - items are sorted across page boundaries
+type AssertItems func(items []interface{}) error
+
+// Page is a received page of paginated request
+type Page struct {
+	// Number is a number of page, starting from 1
+	Number int
+	// Request is a request of page, it could be cloned for next page
+	Request  *http.Request
+	Response *http.Response
+	// Body is a plain body of response
+	Body []byte
+	// Items are items of page, which are found by Pagination.Items
+	Items []interface{}
+}
+
+// NextPage returns request of next page, nil request means that pages are exhausted
+// Ready-made functions: NextByCursor, NextByLinkHeader, NextByPage and NextByOffset.
+type NextPage func(page *Page) (*http.Request, error)
+
+// Pagination is a configuration of paginated request, see RequestPagination
+type Pagination struct {
+	// Next returns request of next page
+	Next NextPage
+	// Items is a jsonpath expression of items in body of page, for example $.items
+	// If it's empty, body of page has to be JSON array.
+	Items string
+	// MaxPages is a safety limit of pages, default is 100
+	// Test is failed, if pages are not exhausted after MaxPages.
+	MaxPages int
+}
+
+// NextByCursor is a function for follow cursor, which is taken from body by jsonpath expression
+// Cursor is set to query parameter param. Pages are exhausted, if cursor is not found, null or empty.
+// Example: NextByCursor("cursor", "$.next_cursor")
+func NextByCursor(param, cursor string) NextPage {
+	return func(page *Page) (*http.Request, error) {
+		values, err := findJSONValues(page.Body, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(values) == 0 || values[0] == nil || fmt.Sprint(values[0]) == "" {
+			return nil, nil
+		}
+
+		return withQueryParam(page.Request, param, fmt.Sprint(values[0])), nil
+	}
+}
+
+// NextByLinkHeader is a function for follow url of Link header with rel="next"
+// Pages are exhausted, if response has no next link.
+func NextByLinkHeader() NextPage {
+	return func(page *Page) (*http.Request, error) {
+		link := nextLink(page.Response.Header.Values("Link"))
+		if link == "" {
+			return nil, nil
+		}
+
+		u, err := page.Request.URL.Parse(link)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse next link %v error: '%s'", link, err)
+		}
+
+		return withURL(page.Request, u), nil
+	}
+}
+
+// NextByPage is a function for increment number of page in query parameter param
+// Number of the first page is taken from request, default is 1. Pages are exhausted, if page has no items.
+func NextByPage(param string) NextPage {
+	return func(page *Page) (*http.Request, error) {
+		if len(page.Items) == 0 {
+			return nil, nil
+		}
+
+		number := 1
+
+		if value := page.Request.URL.Query().Get(param); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse page %v=%v error: '%s'", param, value, err)
+			}
+
+			number = n
+		}
+
+		return withQueryParam(page.Request, param, strconv.Itoa(number+1)), nil
+	}
+}
+
+// NextByOffset is a function for increase offset in query parameter param by count of items of page
+// Offset of the first page is taken from request, default is 0. Pages are exhausted, if page has no items.
+func NextByOffset(param string) NextPage {
+	return func(page *Page) (*http.Request, error) {
+		if len(page.Items) == 0 {
+			return nil, nil
+		}
+
+		offset := 0
+
+		if value := page.Request.URL.Query().Get(param); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("could not parse offset %v=%v error: '%s'", param, value, err)
+			}
+
+			offset = n
+		}
+
+		return withQueryParam(page.Request, param, strconv.Itoa(offset+len(page.Items))), nil
+	}
+}
+
+func withQueryParam(req *http.Request, param, value string) *http.Request {
+	u := *req.URL
+	query := u.Query()
+	query.Set(param, value)
+	u.RawQuery = query.Encode()
+
+	return withURL(req, &u)
+}
+
+func withURL(req *http.Request, u *url.URL) *http.Request {
+	next := req.Clone(req.Context())
+	next.URL = u
+
+	// Host of next link could differ from host of request
+	if u.Host != req.URL.Host {
+		next.Host = ""
+	}
+
+	return next
+}
+
+// nextLink returns url of link with rel="next" from Link headers (RFC 8288)
+func nextLink(headers []string) string {
+	for _, header := range headers {
+		for _, link := range strings.Split(header, ",") {
+			parts := strings.Split(link, ";")
+
+			target := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+				continue
+			}
+
+			for _, param := range parts[1:] {
+				name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || !strings.EqualFold(name, "rel") {
+					continue
+				}
+
+				for _, rel := range strings.Fields(strings.Trim(value, `"`)) {
+					if strings.EqualFold(rel, "next") {
+						return strings.Trim(target, "<>")
+					}
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+func findJSONValues(body []byte, expression string) ([]interface{}, error) {
+	obj, err := oj.Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse json error: '%s'", err)
+	}
+
+	path, err := jp.ParseString(expression)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse path %v error: '%s'", expression, err)
+	}
+
+	return path.Get(obj), nil
+}
+
+// pageItems returns items of page by jsonpath expression
+func pageItems(body []byte, expression string) ([]interface{}, error) {
+	if expression == "" {
+		expression = "$"
+	}
+
+	values, err := findJSONValues(body, expression)
+	if err != nil {
+		return nil, err
+	}
+
+	// Expression could point to array, like $.items, or to items, like $.items[*]
+	if len(values) == 1 {
+		if items, ok := values[0].([]interface{}); ok {
+			return items, nil
+		}
+	}
+
+	return values, nil
+}
+
+// startPaginatedTest follows pages until they are exhausted, every page is validated by Expect in own allure step
+// Then items of all pages are validated by AssertItems. After is executed with response of the last page.
+func (it *Test) startPaginatedTest(t internalT, req *http.Request) (*http.Response, []error) {
+	var (
+		resp  *http.Response
+		items = make([]interface{}, 0)
+		errs  = make([]error, 0)
+	)
+
+	t.WithNewStep(it.stepName("Pagination"), func(groupCtx provider.StepCtx) {
+		resp, items, errs = it.walkPages(groupCtx, req)
+
+		if len(errs) != 0 {
+			groupCtx.CurrentStep().Status = stepStatus(errs)
+		}
+	})
+
+	it.Info(t, "Received %v items", len(items))
+
+	errs = append(errs, it.assertItems(t, items)...)
+
+	if resp == nil {
+		return nil, errs
+	}
+
+	errs = append(errs, it.afterTest(t, resp, errs)...)
+	if len(errs) > 0 {
+		return resp, errs
+	}
+
+	return resp, nil
+}
+
+func (it *Test) walkPages(t provider.StepCtx, req *http.Request) (*http.Response, []interface{}, []error) {
+	var (
+		pagination = it.Request.Pagination
+		maxPages   = pagination.MaxPages
+		resp       *http.Response
+		items      = make([]interface{}, 0)
+		errs       = make([]error, 0)
+	)
+
+	if maxPages == 0 {
+		maxPages = defaultMaxPages
+	}
+
+	for number := 1; ; number++ {
+		var (
+			page     *Page
+			pageErrs []error
+		)
+
+		t.WithNewStep(fmt.Sprintf("Page %v", number), func(pageCtx provider.StepCtx) {
+			page, pageErrs = it.executePage(pageCtx, number, req)
+
+			if len(pageErrs) != 0 {
+				pageCtx.CurrentStep().Status = stepStatus(pageErrs)
+			}
+		})
+
+		errs = append(errs, pageErrs...)
+
+		// Request is failed, next page is unknown
+		if page == nil {
+			return resp, items, errs
+		}
+
+		resp = page.Response
+		items = append(items, page.Items...)
+
+		next, err := pagination.Next(page)
+		if err != nil {
+			err = cuteErrors.NewCuteError("[Pagination] Could not get next page", err)
+			it.processStepErrors(t, []error{err})
+
+			return resp, items, append(errs, err)
+		}
+
+		if next == nil {
+			return resp, items, errs
+		}
+
+		if number == maxPages {
+			err = cuteErrors.NewEmptyAssertError(
+				"Pagination limit",
+				fmt.Sprintf("pages are not exhausted after %v pages", maxPages))
+			it.processStepErrors(t, []error{err})
+
+			return resp, items, append(errs, err)
+		}
+
+		req = next
+	}
+}
+
+// executePage makes request of page and validates response, returned page is nil, if response is not received
+func (it *Test) executePage(t provider.StepCtx, number int, req *http.Request) (*Page, []error) {
+	// makeRequest sends copy of request, so request stays unread for next page
+	resp, errs := it.makeRequest(t, req)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	errs = it.validateResponse(t, resp)
+
+	responseBody, err := it.readResponseBody(resp)
+	if err != nil {
+		return nil, append(errs, fmt.Errorf("could not get response body. error %w", err))
+	}
+
+	page := &Page{
+		Number:   number,
+		Request:  req,
+		Response: resp,
+		Body:     responseBody.plain,
+	}
+
+	if page.Items, err = pageItems(page.Body, it.Request.Pagination.Items); err != nil {
+		err = cuteErrors.NewCuteError("[Pagination] Could not get items of page", err)
+		it.processStepErrors(t, []error{err})
+
+		errs = append(errs, err)
+	}
+
+	t.WithNewParameters("items", len(page.Items))
+
+	return page, errs
+}
+
+func (it *Test) assertItems(t internalT, items []interface{}) []error {
+	if len(it.Expect.AssertItems) == 0 {
+		return nil
+	}
+
+	return it.executeWithStep(t, "Assert items", func(_ T) []error {
+		errs := make([]error, 0)
+
+		for _, f := range it.Expect.AssertItems {
+			if err := f(items); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		return errs
+	})
+}
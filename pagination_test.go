@@ -0,0 +1,133 @@
+package cute
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/stretchr/testify/require"
+)
+
+// newPaginatedServer returns server with ids 1..total, which are split into pages of size items
+// Next page is returned by cursor in body, by Link header or by page/offset query parameters.
+func newPaginatedServer(t *testing.T, total, size int) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		if cursor := query.Get("cursor"); cursor != "" {
+			offset, _ = strconv.Atoi(cursor)
+		}
+
+		if page := query.Get("page"); page != "" {
+			number, _ := strconv.Atoi(page)
+			offset = (number - 1) * size
+		}
+
+		items := make([]map[string]int, 0)
+		for id := offset + 1; id <= total && id <= offset+size; id++ {
+			items = append(items, map[string]int{"id": id})
+		}
+
+		body := map[string]interface{}{"items": items, "next_cursor": nil}
+
+		if next := offset + size; next < total {
+			body["next_cursor"] = strconv.Itoa(next)
+
+			w.Header().Set("Link", fmt.Sprintf(`</items?offset=%v>; rel="next", </items>; rel="first"`, next))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestRequestPagination(t *testing.T) {
+	srv := newPaginatedServer(t, 5, 2)
+
+	for name, test := range map[string]struct {
+		next  NextPage
+		pages int
+	}{
+		"cursor": {next: NextByCursor("cursor", "$.next_cursor"), pages: 3},
+		"link":   {next: NextByLinkHeader(), pages: 3},
+		"page":   {next: NextByPage("page"), pages: 4},
+		"offset": {next: NextByOffset("offset"), pages: 4},
+	} {
+		var (
+			items   []interface{}
+			asserts int
+		)
+
+		builder := NewHTTPTestMaker().NewTestBuilder().
+			Title("pagination").
+			Create().
+			RequestPagination(Pagination{Next: test.next, Items: "$.items"}).
+			RequestBuilder(WithURI(srv.URL+"/items"), WithMethod(http.MethodGet)).
+			ExpectStatus(http.StatusOK).
+			AssertBody(func(_ []byte) error {
+				asserts++
+
+				return nil
+			}).
+			AssertItems(func(all []interface{}) error {
+				items = all
+
+				return nil
+			})
+
+		result, failed, _ := executeRecorded(t, builder)
+		require.False(t, failed, name)
+		require.Equal(t, test.pages, asserts, name)
+		require.Len(t, items, 5, name)
+		require.Equal(t, map[string]interface{}{"id": int64(5)}, items[4], name)
+
+		require.Len(t, result.Steps, 2, name)
+		require.Equal(t, "Pagination", result.Steps[0].Name)
+		require.Equal(t, "Assert items", result.Steps[1].Name)
+
+		pages := result.Steps[0].Steps
+		require.Len(t, pages, test.pages, name)
+		require.Equal(t, "Page 1", pages[0].Name)
+		require.Contains(t, pages[0].Parameters, allure.NewParameter("items", 2))
+		require.Equal(t, fmt.Sprintf("GET %v/items", srv.URL), pages[0].Steps[0].Name)
+	}
+}
+
+func TestRequestPaginationFailed(t *testing.T) {
+	srv := newPaginatedServer(t, 5, 2)
+
+	builder := NewHTTPTestMaker().NewTestBuilder().
+		Title("pagination").
+		Create().
+		RequestPagination(Pagination{Next: NextByLinkHeader(), Items: "$.items", MaxPages: 2}).
+		RequestBuilder(WithURI(srv.URL+"/items"), WithMethod(http.MethodGet)).
+		AssertItems(func(items []interface{}) error {
+			if len(items) != 5 {
+				return fmt.Errorf("expected 5 items, but was %v", len(items))
+			}
+
+			return nil
+		})
+
+	result, failed, _ := executeRecorded(t, builder)
+	require.True(t, failed)
+
+	require.Equal(t, allure.Failed, result.Steps[0].Status)
+	require.Len(t, result.Steps[0].Steps, 3)
+	require.Equal(t, "Pagination limit", result.Steps[0].Steps[2].Name)
+	require.Equal(t, allure.Failed, result.Steps[1].Status)
+}
+
+func TestNextLink(t *testing.T) {
+	require.Equal(t, "/b", nextLink([]string{`</a>; rel="prev"`, `</b>; rel="next"`}))
+	require.Equal(t, "https://x/c?p=2", nextLink([]string{`<https://x/c?p=2>; title="x"; rel="last next"`}))
+	require.Equal(t, "", nextLink([]string{`</a>; rel="prev"`, `broken; rel="next"`}))
+}
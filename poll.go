@@ -0,0 +1,248 @@
+package cute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+
+	cuteErrors "github.com/ozontech/cute/errors"
+)
+
+const defaultPollInterval = 1 * time.Second
+
+// PollCondition returns true, if response is terminal and polling has to be stopped, see PollUntil
+// Body is decoded by Content-Encoding of response. Error stops polling and fails test.
+type PollCondition func(resp *http.Response, body []byte) (bool, error)
+
+// Poll is a configuration of polling, see PollUntil
+// Request is sent repeatedly with Interval until Until returns true or Timeout passes.
+type Poll struct {
+	// Until is a condition of terminal response, for example UntilJSON("$.status", "done", "failed")
+	Until PollCondition
+	// Interval is a delay between requests, default is 1 second
+	Interval time.Duration
+	// Backoff is a multiplier of interval after every request, for example 2 doubles interval
+	Backoff float64
+	// MaxInterval is a limit of interval with backoff
+	MaxInterval time.Duration
+	// Timeout is an overall deadline of polling, default is execute timeout of test, see ExpectExecuteTimeout
+	// Execute timeout of test is increased to Timeout, if it's less.
+	Timeout time.Duration
+}
+
+// UntilStatus is a function for poll until response has one of status codes
+func UntilStatus(codes ...int) PollCondition {
+	return func(resp *http.Response, _ []byte) (bool, error) {
+		for _, code := range codes {
+			if resp.StatusCode == code {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+}
+
+// UntilJSON is a function for poll until jsonpath expression value is one of values
+// Values are compared with types of JSON, numbers are compared as float64, so 1 is equal to 1.0, but not to "1".
+// If expression matches several values, response is terminal, if any of them is one of values,
+// for example UntilJSON("$.jobs[*].status", "failed") stops on the first failed job.
+// Response without value by expression is not terminal.
+func UntilJSON(expression string, values ...interface{}) PollCondition {
+	return func(_ *http.Response, body []byte) (bool, error) {
+		found, err := findJSONValues(body, expression)
+		if err != nil {
+			return false, err
+		}
+
+		for _, v := range found {
+			for _, value := range values {
+				if reflect.DeepEqual(normalizeJSONNumber(v), normalizeJSONNumber(value)) {
+					return true, nil
+				}
+			}
+		}
+
+		return false, nil
+	}
+}
+
+// normalizeJSONNumber returns number as float64, because numbers of JSON could be parsed as int64 or float64
+func normalizeJSONNumber(value interface{}) interface{} {
+	v := reflect.ValueOf(value)
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	default:
+		return value
+	}
+}
+
+// nextInterval returns interval after backoff
+func (p *Poll) nextInterval(interval time.Duration) time.Duration {
+	if p.Backoff > 1 {
+		interval = time.Duration(float64(interval) * p.Backoff)
+	}
+
+	if p.MaxInterval != 0 && interval > p.MaxInterval {
+		interval = p.MaxInterval
+	}
+
+	return interval
+}
+
+// startPollTest sends request until response is terminal, intermediate responses are only summarized in allure.
+// Expect is applied to the terminal response, then After is executed with it.
+func (it *Test) startPollTest(t internalT, req *http.Request) (*http.Response, []error) {
+	body, err := newRequestBody(req)
+	if err != nil {
+		return nil, []error{cuteErrors.NewCuteError("[Internal] Could not read request body", err)}
+	}
+
+	exchange, attempts, pollErr := it.poll(req, body)
+	if exchange == nil {
+		return nil, []error{pollErr}
+	}
+
+	var errs []error
+
+	t.WithNewStep(it.stepName(fmt.Sprintf("[Poll] %v", it.createTitle(1, 1, req))), func(stepCtx provider.StepCtx) {
+		stepCtx.WithNewParameters("attempts", len(attempts))
+		stepCtx.WithAttachments(allure.NewAttachment("Attempts", allure.Text, []byte(it.redactor.String(strings.Join(attempts, "\n")))))
+
+		if pollErr != nil {
+			// The last exchange is shown to explain, why polling is failed
+			_ = it.reportExchange(stepCtx, exchange, body)
+
+			errs = []error{pollErr}
+		} else {
+			errs = it.reportRaceExchange(stepCtx, exchange, body)
+		}
+
+		it.processStepErrors(stepCtx, errs)
+	})
+
+	if pollErr != nil {
+		return exchange.resp, errs
+	}
+
+	errs = append(errs, it.afterTest(t, exchange.resp, errs)...)
+	if len(errs) > 0 {
+		return exchange.resp, errs
+	}
+
+	return exchange.resp, nil
+}
+
+// poll sends copies of request until condition is met, it returns the last exchange and summary of attempts
+func (it *Test) poll(req *http.Request, body *requestBody) (*raceExchange, []string, error) {
+	var (
+		poll     = it.Request.Poll
+		interval = poll.Interval
+		timeout  = poll.Timeout
+		attempts = make([]string, 0)
+	)
+
+	if interval == 0 {
+		interval = defaultPollInterval
+	}
+
+	if timeout == 0 {
+		timeout = it.Expect.ExecuteTime
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	for number := 1; ; number++ {
+		exchanges, err := newRaceExchanges(req, body, 1)
+		if err != nil {
+			return nil, attempts, err
+		}
+
+		exchange := exchanges[0]
+		start := time.Now()
+
+		it.sendRaceRequest(exchange)
+
+		done, err := it.checkPollCondition(exchange)
+		attempts = append(attempts, pollAttempt(number, exchange, time.Since(start), done))
+
+		if err != nil {
+			return exchange, attempts, cuteErrors.NewCuteError("[Poll] Could not check condition", err)
+		}
+
+		if done {
+			return exchange, attempts, nil
+		}
+
+		if time.Now().Add(interval).After(deadline) {
+			return exchange, attempts, cuteErrors.NewEmptyAssertError(
+				"Poll timeout",
+				fmt.Sprintf("condition is not met after %v attempts in %v, last attempt: %v", number, timeout, attempts[len(attempts)-1]))
+		}
+
+		if err = sleepContext(req.Context(), interval); err != nil {
+			return exchange, attempts, cuteErrors.NewCuteError("[Poll] Polling is interrupted", err)
+		}
+
+		interval = poll.nextInterval(interval)
+	}
+}
+
+// checkPollCondition returns true, if response of exchange is terminal
+// Response with transport error is not terminal.
+func (it *Test) checkPollCondition(exchange *raceExchange) (bool, error) {
+	if exchange.err != nil {
+		return false, nil
+	}
+
+	body, err := it.readResponseBody(exchange.resp)
+	if err != nil {
+		return false, err
+	}
+
+	if body.decodeErr != nil {
+		return false, body.decodeErr
+	}
+
+	return it.Request.Poll.Until(exchange.resp, body.plain)
+}
+
+// pollAttempt returns compact summary of attempt, for example "#1 202 Accepted in 15ms"
+func pollAttempt(number int, exchange *raceExchange, duration time.Duration, done bool) string {
+	var result string
+
+	switch {
+	case exchange.err != nil:
+		result = fmt.Sprintf("error: %v", exchange.err)
+	case done:
+		result = fmt.Sprintf("%v, terminal", exchange.resp.Status)
+	default:
+		result = exchange.resp.Status
+	}
+
+	return fmt.Sprintf("#%v %v in %v", number, result, duration.Round(time.Millisecond))
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
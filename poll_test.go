@@ -0,0 +1,155 @@
+package cute
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ozontech/cute/internal/utils"
+)
+
+// newPollServer returns server, which answers 202 with status pending until request number done
+// Body is compressed by gzip, if client accepts it.
+func newPollServer(t *testing.T, done int32) (*httptest.Server, *int32) {
+	var requests int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			number = atomic.AddInt32(&requests, 1)
+			code   = http.StatusOK
+			body   = []byte(`{"status":"done","result":42}`)
+		)
+
+		if number < done {
+			code = http.StatusAccepted
+			body = []byte(`{"status":"pending"}`)
+		}
+
+		if r.Header.Get("Accept-Encoding") == "gzip" {
+			body, _ = utils.EncodeBody("gzip", body)
+
+			w.Header().Set("Content-Encoding", "gzip")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &requests
+}
+
+func TestPollUntil(t *testing.T) {
+	for _, encoding := range []string{"", "gzip"} {
+		testPollUntil(t, encoding)
+	}
+}
+
+func testPollUntil(t *testing.T, encoding string) {
+	srv, requests := newPollServer(t, 3)
+
+	var bodies int
+
+	builder := NewHTTPTestMaker().NewTestBuilder().
+		Title("poll").
+		Create().
+		PollUntil(Poll{
+			Until:    UntilJSON("$.status", "done", "failed"),
+			Interval: 10 * time.Millisecond,
+			Backoff:  2,
+		}).
+		RequestBuilder(WithURI(srv.URL), WithMethod(http.MethodGet), WithHeadersKV("Accept-Encoding", encoding)).
+		ExpectStatus(http.StatusOK).
+		AssertBody(func(body []byte) error {
+			bodies++
+
+			if string(body) != `{"status":"done","result":42}` {
+				return fmt.Errorf("unexpected body %s", body)
+			}
+
+			return nil
+		})
+
+	result, failed, _ := executeRecorded(t, builder)
+	require.False(t, failed, encoding)
+	require.Equal(t, int32(3), atomic.LoadInt32(requests), encoding)
+	require.Equal(t, 1, bodies, encoding)
+
+	require.Len(t, result.Steps, 1)
+
+	step := result.Steps[0]
+	require.Equal(t, fmt.Sprintf("[Poll] GET %v", srv.URL), step.Name)
+	require.Contains(t, step.Parameters, allure.NewParameter("attempts", 3))
+	require.Equal(t, "Attempts", step.Attachments[0].Name)
+}
+
+func TestPollUntilTimeout(t *testing.T) {
+	srv, requests := newPollServer(t, 100)
+
+	builder := NewHTTPTestMaker().NewTestBuilder().
+		Title("poll").
+		Create().
+		PollUntil(Poll{
+			Until:    UntilStatus(http.StatusOK),
+			Interval: 20 * time.Millisecond,
+			Timeout:  100 * time.Millisecond,
+		}).
+		RequestBuilder(WithURI(srv.URL), WithMethod(http.MethodGet))
+
+	result, failed, _ := executeRecorded(t, builder)
+	require.True(t, failed)
+	require.Less(t, atomic.LoadInt32(requests), int32(10))
+
+	step := result.Steps[0]
+	require.Equal(t, allure.Failed, step.Status)
+	require.Equal(t, "Poll timeout", step.Steps[0].Name)
+
+	// The last exchange is attached
+	require.Contains(t, step.Parameters, allure.NewParameter("response_code", "202"))
+	require.Equal(t, "response", step.Attachments[len(step.Attachments)-1].Name)
+}
+
+func TestPollNextInterval(t *testing.T) {
+	poll := &Poll{Backoff: 2, MaxInterval: 3 * time.Second}
+
+	require.Equal(t, 2*time.Second, poll.nextInterval(time.Second))
+	require.Equal(t, 3*time.Second, poll.nextInterval(2*time.Second))
+	require.Equal(t, time.Second, (&Poll{}).nextInterval(time.Second))
+}
+
+func TestUntilJSON(t *testing.T) {
+	for name, tc := range map[string]struct {
+		condition PollCondition
+		body      string
+		expected  bool
+	}{
+		"string":          {UntilJSON("$.status", "done", "failed"), `{"status":"failed"}`, true},
+		"other string":    {UntilJSON("$.status", "done"), `{"status":"pending"}`, false},
+		"int":             {UntilJSON("$.progress", 100), `{"progress":100}`, true},
+		"float to int":    {UntilJSON("$.progress", 1.0), `{"progress":1}`, true},
+		"int to float":    {UntilJSON("$.progress", 1), `{"progress":1.0}`, true},
+		"number string":   {UntilJSON("$.progress", "1"), `{"progress":1}`, false},
+		"bool string":     {UntilJSON("$.ready", "true"), `{"ready":true}`, false},
+		"bool":            {UntilJSON("$.ready", true), `{"ready":true}`, true},
+		"any of matches":  {UntilJSON("$.jobs[*].status", "failed"), `{"jobs":[{"status":"done"},{"status":"failed"}]}`, true},
+		"none of matches": {UntilJSON("$.jobs[*].status", "failed"), `{"jobs":[{"status":"done"}]}`, false},
+		"not found":       {UntilJSON("$.status", "done"), `{}`, false},
+	} {
+		terminal, err := tc.condition(nil, []byte(tc.body))
+		require.NoError(t, err, name)
+		require.Equal(t, tc.expected, terminal, name)
+	}
+}
+
+func TestPollUntilNil(t *testing.T) {
+	require.PanicsWithValue(t, "condition is nil in PollUntil", func() {
+		NewHTTPTestMaker().NewTestBuilder().Create().PollUntil(Poll{})
+	})
+}
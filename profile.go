@@ -0,0 +1,126 @@
+package cute
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"gopkg.in/yaml.v3"
+)
+
+// EnvProfile is an environment variable with name of active profile in LoadProfile
+const EnvProfile = "CUTE_PROFILE"
+
+// Profile is an environment, where tests are run, for example local, staging or pre-prod
+// Relative URIs of requests are resolved from BaseURL, Headers are added to every request,
+// if request has no header with the same name, Timeout is a timeout of http client.
+type Profile struct {
+	Name    string            `yaml:"name"`
+	BaseURL string            `yaml:"base_url"`
+	Headers map[string]string `yaml:"headers"`
+	Timeout time.Duration     `yaml:"timeout"`
+	// Transport configures mTLS, CA files, proxy, protocol and unix socket
+	Transport *TransportConfig `yaml:"transport"`
+}
+
+type profilesFile struct {
+	Default  string              `yaml:"default"`
+	Profiles map[string]*Profile `yaml:"profiles"`
+}
+
+// LoadProfile is a function for load active profile from YAML or JSON file
+// Active profile is taken from CUTE_PROFILE environment variable, or from default field of file.
+// Example of file:
+//
+//	default: local
+//	profiles:
+//	  local:
+//	    base_url: http://localhost:8080
+//	  staging:
+//	    base_url: https://staging.example.com/api
+//	    headers:
+//	      X-Env: staging
+//	    timeout: 10s
+func LoadProfile(path string) (*Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read profiles error: '%s'", err)
+	}
+
+	file := new(profilesFile)
+
+	if err = yaml.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("could not parse profiles error: '%s'", err)
+	}
+
+	name := os.Getenv(EnvProfile)
+	if name == "" {
+		name = file.Default
+	}
+
+	if name == "" {
+		return nil, fmt.Errorf("could not select profile error: 'set %v or default profile in %v'", EnvProfile, path)
+	}
+
+	profile, ok := file.Profiles[name]
+	if !ok || profile == nil {
+		return nil, fmt.Errorf("could not select profile error: 'profile %v is not found, available: %v'", name, file.names())
+	}
+
+	if profile.Name == "" {
+		profile.Name = name
+	}
+
+	return profile, nil
+}
+
+func (f *profilesFile) names() string {
+	names := make([]string, 0, len(f.Profiles))
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
+
+// resolveURL returns url, which is resolved from base url, if url is relative
+// Path of base url is kept, so /users with base http://host/api is http://host/api/users.
+func resolveURL(base, u *url.URL) *url.URL {
+	if base == nil || u.IsAbs() || u.Host != "" {
+		return u
+	}
+
+	res := *base
+	res.RawQuery = u.RawQuery
+	res.Fragment = u.Fragment
+
+	if u.Path != "" {
+		res.Path = strings.TrimSuffix(base.Path, "/") + "/" + strings.TrimPrefix(u.Path, "/")
+		res.RawPath = ""
+	}
+
+	return &res
+}
+
+func (qt *cute) setProfileAllure(t allureProvider) {
+	if qt.baseProps == nil || qt.baseProps.profile == nil {
+		return
+	}
+
+	profile := qt.baseProps.profile
+
+	if profile.Name != "" {
+		t.Label(allure.NewLabel("environment", profile.Name))
+		t.WithNewParameters("environment", profile.Name)
+	}
+
+	if profile.BaseURL != "" {
+		t.WithNewParameters("base_url", profile.BaseURL)
+	}
+}
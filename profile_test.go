@@ -0,0 +1,132 @@
+package cute
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const profiles = `
+default: local
+profiles:
+  local:
+    base_url: http://localhost:8080
+  staging:
+    base_url: https://staging.go.com/api/
+    headers:
+      X-Env: staging
+    timeout: 10s
+`
+
+func TestLoadProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(profiles), 0600))
+
+	t.Setenv(EnvProfile, "")
+
+	profile, err := LoadProfile(path)
+	require.NoError(t, err)
+	require.Equal(t, &Profile{Name: "local", BaseURL: "http://localhost:8080"}, profile)
+
+	t.Setenv(EnvProfile, "staging")
+
+	profile, err = LoadProfile(path)
+	require.NoError(t, err)
+	require.Equal(t, &Profile{
+		Name:    "staging",
+		BaseURL: "https://staging.go.com/api/",
+		Headers: map[string]string{"X-Env": "staging"},
+		Timeout: 10 * time.Second,
+	}, profile)
+
+	t.Setenv(EnvProfile, "prod")
+
+	_, err = LoadProfile(path)
+	require.ErrorContains(t, err, "profile prod is not found, available: local, staging")
+}
+
+func TestResolveURL(t *testing.T) {
+	base, err := url.Parse("https://go.com/api/")
+	require.NoError(t, err)
+
+	for uri, expected := range map[string]string{
+		"/users?id=1":            "https://go.com/api/users?id=1",
+		"users/1":                "https://go.com/api/users/1",
+		"":                       "https://go.com/api/",
+		"http://other.com/users": "http://other.com/users",
+	} {
+		u, err := url.Parse(uri)
+		require.NoError(t, err)
+		require.Equal(t, expected, resolveURL(base, u).String(), uri)
+	}
+}
+
+func TestHTTPTestMakerProfile(t *testing.T) {
+	m := NewHTTPTestMaker(WithProfile(&Profile{
+		Name:    "staging",
+		BaseURL: "https://staging.go.com/api",
+		Headers: map[string]string{"X-Env": "staging", "Authorization": "Bearer default"},
+		Timeout: 5 * time.Second,
+	}))
+
+	require.Equal(t, 5*time.Second, m.httpClient.Timeout)
+
+	ht := createDefaultTest(m)
+	ht.Request.Builders = []RequestBuilder{
+		WithURI("/users"),
+		WithMethod("GET"),
+		WithHeadersKV("authorization", "Bearer token"),
+	}
+
+	req, err := ht.createRequest(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "https://staging.go.com/api/users", req.URL.String())
+	require.Equal(t, "staging", req.Header.Get("X-Env"))
+	require.Equal(t, []string{"Bearer token"}, req.Header["authorization"])
+}
+
+func TestHTTPTestMakerBaseURL(t *testing.T) {
+	m := NewHTTPTestMaker(
+		WithProfile(&Profile{BaseURL: "https://staging.go.com"}),
+		WithBaseURL("http://localhost:8080"),
+		WithCustomHTTPTimeout(time.Second),
+	)
+
+	require.Equal(t, time.Second, m.httpClient.Timeout)
+
+	ht := createDefaultTest(m)
+	ht.Request.Builders = []RequestBuilder{WithURI("/ping"), WithMethod("GET")}
+
+	req, err := ht.createRequest(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:8080/ping", req.URL.String())
+
+	require.Panics(t, func() {
+		NewHTTPTestMaker(WithBaseURL("/relative"))
+	})
+}
+
+func TestHTTPTestMakerProfileTableTest(t *testing.T) {
+	m := NewHTTPTestMaker(WithProfile(&Profile{
+		BaseURL: "https://staging.go.com/api",
+		Headers: map[string]string{"X-Env": "staging"},
+	}))
+
+	ht := &Test{
+		Request: &Request{
+			Builders: []RequestBuilder{WithURI("/users"), WithMethod("GET")},
+		},
+	}
+
+	m.NewTestBuilder().CreateTableTest().PutTests(ht)
+
+	req, err := ht.createRequest(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "https://staging.go.com/api/users", req.URL.String())
+	require.Equal(t, "staging", req.Header.Get("X-Env"))
+}
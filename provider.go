@@ -20,10 +20,12 @@ type allureProvider interface {
 	Broken()
 	BrokenNow()
 	Run(testName string, testBody func(provider.T), tags ...string) (res *allure.Result)
+	Skipf(format string, args ...interface{})
 
 	infoAllureProvider
 	labelsAllureProvider
 	linksAllureProvider
+	parametersProvider
 }
 
 type internalT interface {
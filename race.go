@@ -0,0 +1,311 @@
+package cute
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/framework/provider"
+
+	cuteErrors "github.com/ozontech/cute/errors"
+)
+
+// AssertRace is type for create custom assertions for all responses of race request
+// Responses are in order of requests, response is nil, if request is failed.
+// Example asserts:
+// - exactly one response has status 201, the rest have status 409
+// - all created responses have the same id
+type AssertRace func(responses []*http.Response) error
+
+// raceExchange is a request of race, replay or poll and its result
+type raceExchange struct {
+	req    *http.Request
+	resp   *http.Response
+	err    error
+	waited time.Duration
+}
+
+// startRaceTest sends the same request Request.Race times at the same time, or replays it for Request.Idempotency
+// Every exchange is validated by Expect in own allure step, then all responses are validated by AssertRace
+// and idempotency asserts. After is executed with the first received response.
+func (it *Test) startRaceTest(t internalT, req *http.Request) (*http.Response, []error) {
+	var (
+		idempotency = it.Request.Idempotency
+		count       = it.Request.Race
+		stepName    = "Race"
+		exchanges   []*raceExchange
+	)
+
+	if idempotency != nil {
+		idempotency.setKey(req)
+
+		count = idempotency.count()
+		stepName = "Replay"
+	}
+
+	body, err := newRequestBody(req)
+	if err != nil {
+		return nil, []error{cuteErrors.NewCuteError("[Internal] Could not read request body", err)}
+	}
+
+	if idempotency == nil || idempotency.Concurrent {
+		exchanges, err = it.sendRace(req, body, count)
+	} else {
+		exchanges, err = it.sendReplay(req, body, count, idempotency.Delay)
+	}
+
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	var (
+		resp      *http.Response
+		responses = make([]*http.Response, len(exchanges))
+		errs      = make([]error, 0)
+	)
+
+	for i, exchange := range exchanges {
+		name := fmt.Sprintf("[%v %v/%v] %v", stepName, i+1, len(exchanges), it.createTitle(1, 1, req))
+
+		errs = append(errs, it.executeRaceStep(t, name, exchange, body)...)
+
+		responses[i] = exchange.resp
+		if resp == nil {
+			resp = exchange.resp
+		}
+	}
+
+	errs = append(errs, it.assertRace(t, responses)...)
+
+	if idempotency != nil {
+		errs = append(errs, it.assertIdempotency(t, responses)...)
+	}
+
+	if resp == nil {
+		return nil, errs
+	}
+
+	errs = append(errs, it.afterTest(t, resp, errs)...)
+	if len(errs) > 0 {
+		return resp, errs
+	}
+
+	return resp, nil
+}
+
+// newRaceExchanges returns exchanges with copies of request
+func newRaceExchanges(req *http.Request, body *requestBody, count int) ([]*raceExchange, error) {
+	exchanges := make([]*raceExchange, count)
+
+	for i := range exchanges {
+		copied, err := body.newRequest(req)
+		if err != nil {
+			return nil, cuteErrors.NewCuteError("[Internal] Could not copy request", err)
+		}
+
+		exchanges[i] = &raceExchange{req: copied}
+	}
+
+	return exchanges, nil
+}
+
+// sendReplay sends copies of request one by one with delay
+func (it *Test) sendReplay(req *http.Request, body *requestBody, count int, delay time.Duration) ([]*raceExchange, error) {
+	exchanges, err := newRaceExchanges(req, body, count)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, exchange := range exchanges {
+		if i != 0 && delay != 0 {
+			time.Sleep(delay)
+		}
+
+		it.sendRaceRequest(exchange)
+	}
+
+	return exchanges, nil
+}
+
+// sendRace sends copies of request concurrently, all requests are started after barrier
+func (it *Test) sendRace(req *http.Request, body *requestBody, count int) ([]*raceExchange, error) {
+	var (
+		ready sync.WaitGroup
+		done  sync.WaitGroup
+		start = make(chan struct{})
+	)
+
+	// Requests are copied before barrier, so goroutines only send them
+	exchanges, err := newRaceExchanges(req, body, count)
+	if err != nil {
+		return nil, err
+	}
+
+	ready.Add(count)
+	done.Add(count)
+
+	for _, exchange := range exchanges {
+		go func(exchange *raceExchange) {
+			defer done.Done()
+
+			ready.Done()
+			<-start
+
+			it.sendRaceRequest(exchange)
+		}(exchange)
+	}
+
+	ready.Wait()
+	close(start)
+	done.Wait()
+
+	return exchanges, nil
+}
+
+// sendRaceRequest sends request and reads response body, it doesn't use allure, because it's executed concurrently
+func (it *Test) sendRaceRequest(exchange *raceExchange) {
+	req := exchange.req
+
+	release, waited, err := it.limiter.wait(req.Context(), req.URL.Host)
+	exchange.waited = waited
+
+	if err != nil {
+		exchange.err = cuteErrors.NewCuteError("[HTTP] Could not wait for request limits", err)
+
+		return
+	}
+
+	defer release()
+
+	resp, err := it.httpClient.Do(req)
+	if resp != nil {
+		it.coverage.record(req, resp.StatusCode)
+
+		exchange.resp = resp
+	}
+
+	if err != nil {
+		exchange.err = cuteErrors.NewCuteError("[HTTP] Could not do request", err)
+
+		// Response is kept only for report of request, so body is closed for reuse of connection
+		if resp != nil && resp.Body != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		return
+	}
+
+	if _, err = it.readResponseBody(resp); err != nil {
+		exchange.err = cuteErrors.NewCuteError("[HTTP] Could not read response body", err)
+	}
+}
+
+// executeRaceStep adds request and response of exchange to allure step and validates response
+func (it *Test) executeRaceStep(t internalT, name string, exchange *raceExchange, body *requestBody) []error {
+	var errs []error
+
+	t.WithNewStep(it.stepName(name), func(stepCtx provider.StepCtx) {
+		errs = it.reportRaceExchange(stepCtx, exchange, body)
+		it.processStepErrors(stepCtx, errs)
+	})
+
+	return errs
+}
+
+func (it *Test) reportRaceExchange(t provider.StepCtx, exchange *raceExchange, body *requestBody) []error {
+	if err := it.reportExchange(t, exchange, body); err != nil {
+		return []error{err}
+	}
+
+	errs := make([]error, 0)
+
+	if err := it.validateResponseCode(exchange.resp); err != nil {
+		errs = append(errs, err)
+	}
+
+	return append(errs, it.validateResponse(t, exchange.resp)...)
+}
+
+// reportExchange adds request and response of exchange to allure step, it returns error of exchange
+func (it *Test) reportExchange(t provider.StepCtx, exchange *raceExchange, body *requestBody) error {
+	var err error
+
+	if exchange.waited != 0 {
+		it.Info(t, "[Request] Waited %v for request limits of host %v", exchange.waited, exchange.req.URL.Host)
+		t.WithNewParameters("limits_wait", exchange.waited.String())
+	}
+
+	// Request is taken from response, because in roundTripper request may be changed
+	req := exchange.req
+	if exchange.resp != nil {
+		req = exchange.resp.Request
+	}
+
+	// Body of request is already read by http client, so we set unread one for report
+	if req.Body, err = body.open(); err != nil {
+		it.Error(t, "Could not open request body for report. error %v", err)
+	}
+
+	if addErr := it.addInformationRequest(t, req); addErr != nil {
+		it.Error(t, "Could not log information about request. error %v", addErr)
+	}
+
+	if exchange.err != nil {
+		return exchange.err
+	}
+
+	if addErr := it.addInformationResponse(t, exchange.resp); addErr != nil {
+		it.Error(t, "Could not log information about response. error %v", addErr)
+	}
+
+	return nil
+}
+
+func (it *Test) assertRace(t internalT, responses []*http.Response) []error {
+	if len(it.Expect.AssertRace) == 0 {
+		return nil
+	}
+
+	bodies := make([]*responseBody, len(responses))
+
+	for i, resp := range responses {
+		if resp == nil {
+			continue
+		}
+
+		if body, ok := resp.Body.(*responseBody); ok {
+			bodies[i] = body
+		}
+	}
+
+	// Original bodies are returned to responses for results of test
+	defer func() {
+		for i, body := range bodies {
+			if body != nil {
+				responses[i].Body = body
+			}
+		}
+	}()
+
+	return it.executeWithStep(t, "Assert race", func(_ T) []error {
+		errs := make([]error, 0)
+
+		for _, f := range it.Expect.AssertRace {
+			// Every assert reads bodies from the beginning by own reader, because truncated body could not be rewound
+			for i, body := range bodies {
+				if body != nil {
+					responses[i].Body = body.newReader()
+				}
+			}
+
+			if err := f(responses); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		return errs
+	})
+}
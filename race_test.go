@@ -0,0 +1,208 @@
+package cute
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/stretchr/testify/require"
+)
+
+// newRaceServer returns server, which creates order once and answers 409 to the rest requests
+// Server waits for count requests, so requests are handled only if they are sent at the same time.
+func newRaceServer(t *testing.T, count int) *httptest.Server {
+	var (
+		mu      sync.Mutex
+		created bool
+		arrived sync.WaitGroup
+	)
+
+	arrived.Add(count)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		arrived.Done()
+		arrived.Wait()
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if created {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"error":"already exists"}`))
+
+			return
+		}
+
+		created = true
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestRequestRace(t *testing.T) {
+	srv := newRaceServer(t, 5)
+
+	var (
+		after     int
+		responses []*http.Response
+	)
+
+	builder := NewHTTPTestMaker().NewTestBuilder().
+		Title("race").
+		Create().
+		RequestRace(5).
+		RequestBuilder(
+			WithURI(srv.URL),
+			WithMethod(http.MethodPost),
+			WithBody([]byte(`{"id":"order"}`)),
+		).
+		ExpectExecuteTimeout(5 * time.Second).
+		AssertRace(func(resps []*http.Response) error {
+			responses = resps
+
+			return nil
+		}).
+		AssertRace(func(resps []*http.Response) error {
+			// Body is read from the beginning in every assert
+			body, err := io.ReadAll(resps[0].Body)
+			if err != nil || len(body) == 0 {
+				return errors.New("body is empty")
+			}
+
+			return nil
+		}).
+		After(func(_ *http.Response, _ []error) error {
+			after++
+
+			return nil
+		})
+
+	result, failed, _ := executeRecorded(t, builder)
+	require.False(t, failed)
+	require.Equal(t, 1, after)
+
+	statuses := make(map[int]int)
+	for _, resp := range responses {
+		statuses[resp.StatusCode]++
+	}
+
+	require.Equal(t, map[int]int{http.StatusCreated: 1, http.StatusConflict: 4}, statuses)
+
+	// Every exchange is shown in own step with request and response
+	require.Len(t, result.Steps, 7)
+
+	for i, step := range result.Steps[:5] {
+		require.Equal(t, fmt.Sprintf("[Race %v/5] POST %v", i+1, srv.URL), step.Name)
+		require.NotEmpty(t, step.Attachments)
+	}
+
+	require.Equal(t, "Assert race", result.Steps[5].Name)
+	require.Equal(t, "After", result.Steps[6].Name)
+}
+
+func TestRequestRaceTruncatedBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"order","description":"long description"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	bodies := make([]string, 0, 2)
+
+	readFirst := func(resps []*http.Response) error {
+		body, err := io.ReadAll(resps[0].Body)
+		if err != nil {
+			return err
+		}
+
+		bodies = append(bodies, string(body))
+
+		return nil
+	}
+
+	builder := NewHTTPTestMaker(WithMaxBodySize(8)).NewTestBuilder().
+		Title("race").
+		Create().
+		RequestRace(2).
+		RequestBuilder(WithURI(srv.URL)).
+		AssertRace(readFirst).
+		AssertRace(readFirst)
+
+	_, failed, _ := executeRecorded(t, builder)
+	require.False(t, failed)
+
+	// Every assert reads the same buffered part of truncated body
+	require.Equal(t, []string{`{"id":"o`, `{"id":"o`}, bodies)
+}
+
+func TestRequestRaceFailed(t *testing.T) {
+	srv := newRaceServer(t, 3)
+
+	builder := NewHTTPTestMaker().NewTestBuilder().
+		Title("race").
+		Create().
+		RequestRace(3).
+		RequestBuilder(WithURI(srv.URL), WithMethod(http.MethodPost)).
+		ExpectExecuteTimeout(5 * time.Second).
+		ExpectStatus(http.StatusCreated)
+
+	result, failed, _ := executeRecorded(t, builder)
+	require.True(t, failed)
+
+	var failedSteps int
+
+	for _, step := range result.Steps {
+		if step.Status == allure.Failed {
+			failedSteps++
+		}
+	}
+
+	require.Equal(t, 2, failedSteps)
+}
+
+func TestRequestModesCombined(t *testing.T) {
+	poll := &Poll{Until: UntilStatus(http.StatusOK)}
+
+	for expected, request := range map[string]*Request{
+		"could not combine RequestRace, PollUntil in one request":                     {Race: 2, Poll: poll},
+		"could not combine RequestIdempotency, RequestPagination in one request":      {Idempotency: &Idempotency{}, Pagination: &Pagination{}},
+		"could not combine RequestRace with RequestRetry":                             {Race: 2, Retry: &RequestRetryPolitic{Count: 3}},
+		"could not combine PollUntil with RequestRetry":                               {Poll: poll, Retry: &RequestRetryPolitic{Count: 2}},
+		"could not combine RequestRace, RequestIdempotency, PollUntil in one request": {Race: 3, Idempotency: &Idempotency{}, Poll: poll},
+	} {
+		if request.Retry == nil {
+			request.Retry = new(RequestRetryPolitic)
+		}
+
+		require.EqualError(t, request.validateModes(), expected)
+	}
+
+	// Retry is applied to every page
+	require.NoError(t, (&Request{Pagination: &Pagination{}, Retry: &RequestRetryPolitic{Count: 3}}).validateModes())
+
+	srv := newRaceServer(t, 1)
+
+	builder := NewHTTPTestMaker().NewTestBuilder().
+		Title("combined").
+		Create().
+		RequestRetry(3).
+		RequestIdempotency(Idempotency{}).
+		RequestBuilder(WithURI(srv.URL), WithMethod(http.MethodPost))
+
+	result, failed, _ := executeRecorded(t, builder)
+	require.True(t, failed)
+	require.Empty(t, result.Steps)
+}
@@ -0,0 +1,342 @@
+package cute
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ohler55/ojg/jp"
+	"github.com/ohler55/ojg/oj"
+)
+
+const (
+	defaultRedactionMask = "****"
+
+	// minRecordedSecretLength is a minimal length of masked value, which is also hidden in error messages.
+	// Short values like "1" or "ok" are not hidden, because they would corrupt any message.
+	minRecordedSecretLength = 4
+)
+
+// RedactionPolicy is a set of rules for hide sensitive data in logs and allure report.
+// Policy is applied to copies of requests and responses, so real requests, responses and asserts are not changed.
+// Values, which were masked in request or response, are also hidden in error messages.
+type RedactionPolicy struct {
+	// Headers is a list of header names, which values are masked. Names are case-insensitive.
+	Headers []string
+	// QueryKeys is a list of query parameters, which values are masked.
+	QueryKeys []string
+	// RequestJSONPaths is a list of JSONPath expressions, which values are masked in request body.
+	// About expression - https://goessner.net/articles/JsonPath/
+	RequestJSONPaths []string
+	// ResponseJSONPaths is a list of JSONPath expressions, which values are masked in response body.
+	// About expression - https://goessner.net/articles/JsonPath/
+	ResponseJSONPaths []string
+	// Patterns is a list of regular expressions, which matches are masked in curl, parameters,
+	// step titles, attachments and error messages.
+	// If pattern has groups, only groups are masked, for example `token=(\w+)`.
+	Patterns []*regexp.Regexp
+	// Mask is a replacement for sensitive values. Default is "****".
+	Mask string
+}
+
+// redactionRules is a compiled RedactionPolicy, it's compiled once in NewHTTPTestMaker and shared between tests.
+type redactionRules struct {
+	policy            *RedactionPolicy
+	mask              string
+	headers           map[string]struct{}
+	queryKeys         map[string]struct{}
+	requestJSONPaths  []jp.Expr
+	responseJSONPaths []jp.Expr
+}
+
+// redactor applies redaction rules and remembers masked values for hide them in error messages.
+// Every test has own redactor, because masked values are remembered.
+// nil redactor doesn't change anything.
+type redactor struct {
+	*redactionRules
+
+	mu      sync.RWMutex
+	secrets map[string]struct{}
+}
+
+// compileRedactionPolicy validates policy and compiles its JSON paths, nil policy returns nil rules
+func compileRedactionPolicy(policy *RedactionPolicy) (*redactionRules, error) {
+	if policy == nil {
+		return nil, nil
+	}
+
+	var (
+		err   error
+		rules = &redactionRules{
+			policy:    policy,
+			mask:      policy.Mask,
+			headers:   make(map[string]struct{}, len(policy.Headers)),
+			queryKeys: make(map[string]struct{}, len(policy.QueryKeys)),
+		}
+	)
+
+	if rules.mask == "" {
+		rules.mask = defaultRedactionMask
+	}
+
+	for _, name := range policy.Headers {
+		rules.headers[http.CanonicalHeaderKey(name)] = struct{}{}
+	}
+
+	for _, key := range policy.QueryKeys {
+		rules.queryKeys[key] = struct{}{}
+	}
+
+	if rules.requestJSONPaths, err = parseJSONPaths(policy.RequestJSONPaths); err != nil {
+		return nil, err
+	}
+
+	if rules.responseJSONPaths, err = parseJSONPaths(policy.ResponseJSONPaths); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func newRedactor(rules *redactionRules) *redactor {
+	if rules == nil {
+		return nil
+	}
+
+	return &redactor{
+		redactionRules: rules,
+		secrets:        make(map[string]struct{}),
+	}
+}
+
+func parseJSONPaths(expressions []string) ([]jp.Expr, error) {
+	res := make([]jp.Expr, 0, len(expressions))
+
+	for _, expression := range expressions {
+		x, err := jp.ParseString(expression)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse redaction JSON path %v error: '%s'", expression, err)
+		}
+
+		res = append(res, x)
+	}
+
+	return res, nil
+}
+
+// Request masks headers and query parameters of request
+// Request must be a copy, because it is changed.
+func (r *redactor) Request(req *http.Request) {
+	if r == nil {
+		return
+	}
+
+	req.Header = r.Headers(req.Header)
+
+	if req.URL != nil && len(r.queryKeys) != 0 {
+		query := req.URL.Query()
+		changed := false
+
+		for key, values := range query {
+			if _, ok := r.queryKeys[key]; !ok {
+				continue
+			}
+
+			for i, value := range values {
+				r.remember(value)
+				values[i] = r.mask
+			}
+
+			changed = true
+		}
+
+		if changed {
+			// mask is kept unescaped for readability
+			req.URL.RawQuery = strings.ReplaceAll(query.Encode(), url.QueryEscape(r.mask), r.mask)
+		}
+	}
+}
+
+// Headers returns copy of headers with masked values
+func (r *redactor) Headers(headers http.Header) http.Header {
+	if r == nil || headers == nil {
+		return headers
+	}
+
+	res := headers.Clone()
+
+	for name, values := range res {
+		if _, ok := r.headers[http.CanonicalHeaderKey(name)]; !ok {
+			continue
+		}
+
+		masked := make([]string, len(values))
+
+		for i, value := range values {
+			r.remember(value)
+			masked[i] = r.mask
+		}
+
+		res[name] = masked
+	}
+
+	return res
+}
+
+// RequestBody masks JSON paths in request body
+func (r *redactor) RequestBody(body []byte) []byte {
+	if r == nil {
+		return body
+	}
+
+	return r.jsonBody(body, r.requestJSONPaths)
+}
+
+// ResponseBody masks JSON paths in response body
+func (r *redactor) ResponseBody(body []byte) []byte {
+	if r == nil {
+		return body
+	}
+
+	return r.jsonBody(body, r.responseJSONPaths)
+}
+
+func (r *redactor) jsonBody(body []byte, paths []jp.Expr) []byte {
+	if len(paths) == 0 || len(body) == 0 {
+		return body
+	}
+
+	obj, err := oj.Parse(body)
+	// not JSON body is not changed by JSON paths
+	if err != nil {
+		return body
+	}
+
+	changed := false
+
+	for _, x := range paths {
+		obj, err = x.Modify(obj, func(element any) (any, bool) {
+			r.remember(element)
+			changed = true
+
+			return r.mask, true
+		})
+		if err != nil {
+			return body
+		}
+	}
+
+	if !changed {
+		return body
+	}
+
+	return []byte(oj.JSON(obj))
+}
+
+// String masks regexp patterns and remembered values in text
+func (r *redactor) String(s string) string {
+	if r == nil {
+		return s
+	}
+
+	for _, re := range r.policy.Patterns {
+		s = r.maskPattern(re, s)
+	}
+
+	r.mu.RLock()
+	secrets := make([]string, 0, len(r.secrets))
+	for secret := range r.secrets {
+		secrets = append(secrets, secret)
+	}
+	r.mu.RUnlock()
+
+	// longer values first, because value could contain another one
+	sort.Slice(secrets, func(i, j int) bool {
+		return len(secrets[i]) > len(secrets[j])
+	})
+
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, r.mask)
+	}
+
+	return s
+}
+
+// Bytes masks regexp patterns and remembered values in data
+func (r *redactor) Bytes(data []byte) []byte {
+	if r == nil {
+		return data
+	}
+
+	return []byte(r.String(string(data)))
+}
+
+// Value masks string representation of value
+// If nothing is masked, value is returned as is.
+func (r *redactor) Value(v interface{}) interface{} {
+	if r == nil {
+		return v
+	}
+
+	s := fmt.Sprint(v)
+
+	if masked := r.String(s); masked != s {
+		return masked
+	}
+
+	return v
+}
+
+func (r *redactor) maskPattern(re *regexp.Regexp, s string) string {
+	if re.NumSubexp() == 0 {
+		return re.ReplaceAllString(s, r.mask)
+	}
+
+	var (
+		b    strings.Builder
+		last = 0
+	)
+
+	for _, match := range re.FindAllStringSubmatchIndex(s, -1) {
+		for i := 2; i < len(match); i += 2 {
+			start, end := match[i], match[i+1]
+			if start < last || start == -1 {
+				continue
+			}
+
+			b.WriteString(s[last:start])
+			b.WriteString(r.mask)
+			last = end
+		}
+	}
+
+	b.WriteString(s[last:])
+
+	return b.String()
+}
+
+func (r *redactor) remember(v interface{}) {
+	var s string
+
+	switch value := v.(type) {
+	case string:
+		s = value
+	case nil, bool, map[string]interface{}, []interface{}:
+		return
+	default:
+		s = fmt.Sprint(value)
+	}
+
+	if len(s) < minRecordedSecretLength {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.secrets[s] = struct{}{}
+}
@@ -0,0 +1,179 @@
+package cute
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactorNil(t *testing.T) {
+	var r *redactor
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost/api?key=123", nil)
+	require.NoError(t, err)
+
+	r.Request(req)
+	require.Equal(t, "key=123", req.URL.RawQuery)
+	require.Equal(t, "secret", r.String("secret"))
+	require.Equal(t, []byte(`{"a":1}`), r.ResponseBody([]byte(`{"a":1}`)))
+	require.Equal(t, 1, r.Value(1))
+}
+
+func TestRedactorRequest(t *testing.T) {
+	r := mustRedactor(t, &RedactionPolicy{
+		Headers:   []string{"authorization"},
+		QueryKeys: []string{"token"},
+	})
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost/api?token=secret_token&page=1", nil)
+	require.NoError(t, err)
+
+	req.Header.Set("Authorization", "Bearer secret_header")
+	req.Header.Set("Accept", "application/json")
+
+	r.Request(req)
+
+	require.Equal(t, "****", req.Header.Get("Authorization"))
+	require.Equal(t, "application/json", req.Header.Get("Accept"))
+	require.Equal(t, "****", req.URL.Query().Get("token"))
+	require.Contains(t, req.URL.RawQuery, "token=****")
+	require.Equal(t, "1", req.URL.Query().Get("page"))
+
+	// masked values are hidden in any text
+	require.Equal(t, "error: **** is invalid, ****", r.String("error: secret_token is invalid, Bearer secret_header"))
+}
+
+func TestRedactorJSONPaths(t *testing.T) {
+	r := mustRedactor(t, &RedactionPolicy{
+		RequestJSONPaths:  []string{"$.password"},
+		ResponseJSONPaths: []string{"$.items[*].card"},
+		Mask:              "[hidden]",
+	})
+
+	body := r.RequestBody([]byte(`{"login":"user","password":"qwerty123"}`))
+	require.JSONEq(t, `{"login":"user","password":"[hidden]"}`, string(body))
+
+	body = r.ResponseBody([]byte(`{"items":[{"card":"4111111111111111"},{"card":"5500000000000004"}]}`))
+	require.JSONEq(t, `{"items":[{"card":"[hidden]"},{"card":"[hidden]"}]}`, string(body))
+
+	// not JSON body is not changed
+	require.Equal(t, "password=qwerty123", string(r.RequestBody([]byte("password=qwerty123"))))
+
+	require.Equal(t, "expected [hidden]", r.String("expected 4111111111111111"))
+}
+
+func TestRedactorPatterns(t *testing.T) {
+	r := mustRedactor(t, &RedactionPolicy{
+		Patterns: []*regexp.Regexp{
+			regexp.MustCompile(`token=(\w+)`),
+			regexp.MustCompile(`\d{4}-\d{4}`),
+		},
+	})
+
+	require.Equal(t, "token=**** and ****", r.String("token=abc and 1234-5678"))
+}
+
+func TestRedactorInvalidJSONPath(t *testing.T) {
+	_, err := compileRedactionPolicy(&RedactionPolicy{ResponseJSONPaths: []string{"$.token", "$.["}})
+	require.ErrorContains(t, err, "could not parse redaction JSON path $.[")
+
+	// Policy is validated once, when maker is created
+	require.Panics(t, func() {
+		NewHTTPTestMaker(WithRedactionPolicy(&RedactionPolicy{RequestJSONPaths: []string{"$.["}}))
+	})
+}
+
+func TestRedactionRulesAreShared(t *testing.T) {
+	m := NewHTTPTestMaker(WithRedactionPolicy(&RedactionPolicy{Headers: []string{"Authorization"}}))
+
+	first, second := createDefaultTest(m), createDefaultTest(m)
+	require.Same(t, first.redactor.redactionRules, second.redactor.redactionRules)
+
+	// Masked values are remembered by test
+	first.redactor.remember("secret_value")
+	require.Equal(t, "****", first.redactor.String("secret_value"))
+	require.Equal(t, "secret_value", second.redactor.String("secret_value"))
+}
+
+func mustRedactor(t *testing.T, policy *RedactionPolicy) *redactor {
+	rules, err := compileRedactionPolicy(policy)
+	require.NoError(t, err)
+
+	return newRedactor(rules)
+}
+
+func TestRedactionPolicyDoesNotChangeRequest(t *testing.T) {
+	var (
+		sentBody   string
+		sentHeader string
+	)
+
+	client := &http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			data, err := io.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+
+			sentBody = string(data)
+			sentHeader = req.Header.Get("X-Api-Key")
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Request:    req,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       io.NopCloser(strings.NewReader(`{"token":"response_secret"}`)),
+			}, nil
+		}),
+	}
+
+	test := &Test{
+		httpClient: client,
+		redactor: mustRedactor(t, &RedactionPolicy{
+			Headers:           []string{"X-Api-Key"},
+			RequestJSONPaths:  []string{"$.password"},
+			ResponseJSONPaths: []string{"$.token"},
+		}),
+		Request: &Request{
+			Builders: []RequestBuilder{
+				WithMethod(http.MethodPost),
+				WithURI("http://localhost/api"),
+				WithHeadersKV("X-Api-Key", "api_key_secret"),
+				WithBody([]byte(`{"password":"qwerty123"}`)),
+			},
+		},
+	}
+
+	test.initEmptyFields()
+
+	req, err := test.createRequest(context.Background())
+	require.NoError(t, err)
+
+	resp, errs := test.makeRequest(createAllureT(t), req)
+	require.Empty(t, errs)
+
+	require.Equal(t, `{"password":"qwerty123"}`, sentBody)
+	require.Equal(t, "api_key_secret", sentHeader)
+	require.Equal(t, "api_key_secret", req.Header.Get("X-Api-Key"))
+
+	// asserts get real response
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, `{"token":"response_secret"}`, string(data))
+
+	// values, which were masked in report, are hidden in errors
+	msg := test.redactor.String(errors.New("token response_secret, password qwerty123").Error())
+	require.Equal(t, "token ****, password ****", msg)
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
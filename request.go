@@ -2,6 +2,14 @@ package cute
 
 import (
 	"net/url"
+	"text/template"
+)
+
+// Content encodings, which can be used in WithContentEncoding
+const (
+	EncodingGzip    = "gzip"
+	EncodingDeflate = "deflate"
+	EncodingZstd    = "zstd"
 )
 
 // RequestBuilder is a function for set options in request
@@ -27,6 +35,14 @@ type requestOptions struct {
 	bodyMarshal interface{}
 	fileForms   map[string]*File
 	forms       map[string][]byte
+
+	contentEncoding string
+
+	// templateFuncs are functions of body templates, they are set by test
+	templateFuncs template.FuncMap
+
+	// err is an error of builder, request is not created, if error is set
+	err error
 }
 
 func newRequestOptions() *requestOptions {
@@ -136,3 +152,11 @@ func WithForm(forms map[string][]byte) func(o *requestOptions) {
 		}
 	}
 }
+
+// WithContentEncoding is a function for compress body and set Content-Encoding header in request
+// Available encodings: EncodingGzip, EncodingDeflate, EncodingZstd
+func WithContentEncoding(encoding string) func(o *requestOptions) {
+	return func(o *requestOptions) {
+		o.contentEncoding = encoding
+	}
+}
@@ -1,6 +1,7 @@
 package cute
 
 import (
+	"crypto/tls"
 	"net/http"
 )
 
@@ -45,6 +46,22 @@ func (r *testResults) GetName() string {
 	return r.name
 }
 
+func (r *testResults) GetProtocol() string {
+	if r.resp == nil {
+		return ""
+	}
+
+	return r.resp.Proto
+}
+
+func (r *testResults) GetTLSVersion() string {
+	if r.resp == nil || r.resp.TLS == nil {
+		return ""
+	}
+
+	return tls.VersionName(r.resp.TLS.Version)
+}
+
 func (r *testResults) GetResultState() ResultState {
 	if r.state == resultStateFailNow {
 		return ResultStateFail
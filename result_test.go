@@ -1,6 +1,7 @@
 package cute
 
 import (
+	"crypto/tls"
 	"errors"
 	"net/http"
 	"testing"
@@ -33,3 +34,22 @@ func TestResult(t *testing.T) {
 	require.Equal(t, resp, testResults.GetHTTPResponse())
 	require.Equal(t, []error{firstErr, secondErr}, testResults.GetErrors())
 }
+
+func TestResultProtocol(t *testing.T) {
+	results := newTestResult("name", &http.Response{
+		Proto: "HTTP/2.0",
+		TLS:   &tls.ConnectionState{Version: tls.VersionTLS13},
+	}, ResultStateSuccess, nil).(ResultsConnection)
+
+	require.Equal(t, "HTTP/2.0", results.GetProtocol())
+	require.Equal(t, "TLS 1.3", results.GetTLSVersion())
+
+	// Plain connection has no TLS version
+	results = newTestResult("name", &http.Response{Proto: "HTTP/1.1"}, ResultStateSuccess, nil).(ResultsConnection)
+	require.Equal(t, "HTTP/1.1", results.GetProtocol())
+	require.Empty(t, results.GetTLSVersion())
+
+	results = newTestResult("name", nil, ResultStateFail, nil).(ResultsConnection)
+	require.Empty(t, results.GetProtocol())
+	require.Empty(t, results.GetTLSVersion())
+}
@@ -1,7 +1,9 @@
 package cute
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
@@ -34,9 +36,15 @@ func (it *Test) makeRequest(t internalT, req *http.Request) (*http.Response, []e
 		countRepeat = it.Request.Retry.Count
 	}
 
+	// read body once, it will be replayed for every attempt
+	body, err := newRequestBody(req)
+	if err != nil {
+		return nil, []error{cuteErrors.NewCuteError("[Internal] Could not read request body", err)}
+	}
+
 	for i := 1; i <= countRepeat; i++ {
 		it.executeWithStep(t, it.createTitle(i, countRepeat, req), func(t T) []error {
-			resp, err = it.doRequest(t, req)
+			resp, err = it.doRequest(t, req, body)
 			if err != nil {
 				if it.Request.Retry.Broken {
 					err = wrapBrokenError(err)
@@ -66,13 +74,26 @@ func (it *Test) makeRequest(t internalT, req *http.Request) (*http.Response, []e
 	return resp, scope
 }
 
-func (it *Test) doRequest(t T, baseReq *http.Request) (*http.Response, error) {
+func (it *Test) doRequest(t T, baseReq *http.Request, body *requestBody) (*http.Response, error) {
 	// copy request, because body can be read once
-	req, err := copyRequest(baseReq.Context(), baseReq)
+	req, err := body.newRequest(baseReq)
 	if err != nil {
 		return nil, cuteErrors.NewCuteError("[Internal] Could not copy request", err)
 	}
 
+	// Wait for rate limit and free slot of host, every retry waits again
+	release, waited, err := it.limiter.wait(req.Context(), req.URL.Host)
+	if waited != 0 {
+		it.Info(t, "[Request] Waited %v for request limits of host %v", waited, req.URL.Host)
+		t.WithNewParameters("limits_wait", waited.String())
+	}
+
+	if err != nil {
+		return nil, cuteErrors.NewCuteError("[HTTP] Could not wait for request limits", err)
+	}
+
+	defer release()
+
 	resp, httpErr := it.httpClient.Do(req)
 
 	// if the timeout is triggered, we properly log the timeout error on allure and in traces
@@ -100,10 +121,12 @@ func (it *Test) doRequest(t T, baseReq *http.Request) (*http.Response, error) {
 		return nil, cuteErrors.NewCuteError("[HTTP] Response is nil", httpErr)
 	}
 
-	// BAD CODE. Need to copy body, because we can't read body again from resp.Request.Body. Problem is io.Reader
-	resp.Request.Body, baseReq.Body, err = utils.DrainBody(baseReq.Body)
-	if err != nil {
-		it.Error(t, "Could not drain body from baseReq.Body. error %v", err)
+	// Record operation before asserts, coverage doesn't depend on result of test
+	it.coverage.record(req, resp.StatusCode)
+
+	// Body of resp.Request is already read by http client, so we set unread one for report
+	if resp.Request.Body, err = body.open(); err != nil {
+		it.Error(t, "Could not open request body for report. error %v", err)
 		// Ignore err return, because it's connected with test logic
 	}
 
@@ -118,6 +141,11 @@ func (it *Test) doRequest(t T, baseReq *http.Request) (*http.Response, error) {
 		return nil, cuteErrors.NewCuteError("[HTTP] Could not do request", httpErr)
 	}
 
+	// Read response body once, it's shared between Allure and asserts
+	if _, err = it.readResponseBody(resp); err != nil {
+		return nil, cuteErrors.NewCuteError("[HTTP] Could not read response body", err)
+	}
+
 	// Add information (code, body, headers) about response to Allure step
 	if addErr := it.addInformationResponse(t, resp); addErr != nil {
 		// Ignore err return, because it's connected with test logic
@@ -144,111 +172,159 @@ func (it *Test) validateResponseCode(resp *http.Response) error {
 }
 
 func (it *Test) addInformationRequest(t T, req *http.Request) error {
-	var (
-		saveBody io.ReadCloser
-		err      error
-	)
+	body, buffered, err := readRequestBody(req)
+	if err != nil {
+		return err
+	}
 
-	if it.RequestSanitizer != nil {
-		it.RequestSanitizer(req)
+	// if body could not be decoded, show it as is
+	if decoded, _, decodeErr := decodeBody(req.Header, body, 0); decodeErr == nil {
+		body = decoded
 	}
 
-	it.lastRequestURL = req.URL.String()
+	// Sanitizer and redaction policy are applied to copy, so real request is not changed
+	reportReq, body := it.requestForReport(req, body)
+
+	it.lastRequestURL = it.redactor.String(reportReq.URL.String())
 
-	curl, err := http2curl.GetCurlCommand(req)
+	curl, err := http2curl.GetCurlCommand(reportReq)
 	if err != nil {
 		return err
 	}
 
-	if c := curl.String(); len(c) <= 2048 {
-		it.Info(t, "[Request] "+c)
+	curlString := it.redactor.String(curl.String())
+
+	if len(curlString) <= 2048 {
+		it.Info(t, "[Request] %v", curlString)
 	} else {
 		it.Info(t, "[Request] Do request")
 	}
 
 	// Do not change to JSONMarshaler
 	// In this case we can keep default for keep JSON, independence from JSONMarshaler
-	headers, err := utils.ToJSON(req.Header)
+	headers, err := utils.ToJSON(reportReq.Header)
 	if err != nil {
 		return err
 	}
 
 	t.WithParameters(
 		allure.NewParameters(
-			"method", req.Method,
-			"host", req.Host,
-			"headers", headers,
-			"curl", curl.String(),
+			"method", reportReq.Method,
+			"host", it.redactor.String(reportReq.Host),
+			"headers", it.redactor.String(headers),
+			"curl", curlString,
 		)...,
 	)
 
-	if req.Body != nil {
-		saveBody, req.Body, err = utils.DrainBody(req.Body)
-		if err != nil {
-			return err
-		}
+	if !buffered {
+		t.WithNewParameters("body", "[body is streamed from files and is not shown]")
 
-		body, err := utils.GetBody(saveBody)
-		if err != nil {
-			return err
-		}
+		return nil
+	}
 
-		if len(body) != 0 {
-			t.WithNewParameters("body", string(body))
-		}
+	if len(body) != 0 {
+		body, _ = it.truncateForReport(body)
+
+		t.WithNewParameters("body", it.redactor.String(string(body)))
 	}
 
 	return nil
 }
 
-func copyRequest(ctx context.Context, req *http.Request) (*http.Request, error) {
-	var (
-		err error
+// requestForReport returns copy of request and body for logs and allure report.
+// RequestSanitizer hook and redaction policy are applied to copy only.
+func (it *Test) requestForReport(req *http.Request, body []byte) (*http.Request, []byte) {
+	clone := req.Clone(req.Context())
+	clone.Body = nil
 
-		clone = req.Clone(ctx)
-	)
+	if len(body) != 0 {
+		clone.Body = io.NopCloser(bytes.NewReader(body))
+	}
 
-	req.Body, clone.Body, err = utils.DrainBody(req.Body)
-	if err != nil {
-		return nil, err
+	if it.RequestSanitizer != nil {
+		it.RequestSanitizer(clone)
+
+		// hook could change body
+		if clone.Body != nil {
+			if sanitized, err := io.ReadAll(clone.Body); err == nil {
+				body = sanitized
+			}
+		}
+	}
+
+	it.redactor.Request(clone)
+	body = it.redactor.RequestBody(body)
+
+	// http2curl reads body, so copy gets own reader
+	clone.Body = nil
+
+	if len(body) != 0 {
+		clone.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	return clone, body
+}
+
+// responseForReport returns copy of response headers and body for logs and allure report.
+// ResponseSanitizer hook and redaction policy are applied to copy only.
+func (it *Test) responseForReport(response *http.Response, body []byte) (http.Header, []byte) {
+	clone := *response
+	clone.Header = response.Header.Clone()
+	clone.Body = io.NopCloser(bytes.NewReader(body))
+
+	if it.ResponseSanitizer != nil {
+		it.ResponseSanitizer(&clone)
+
+		// hook could change body
+		if clone.Body != nil {
+			if sanitized, err := io.ReadAll(clone.Body); err == nil {
+				body = sanitized
+			}
+		}
 	}
 
-	return clone, nil
+	return it.redactor.Headers(clone.Header), it.redactor.ResponseBody(body)
 }
 
 func (it *Test) addInformationResponse(t T, response *http.Response) error {
 	var (
-		saveBody io.ReadCloser
-		err      error
+		body         []byte
+		responseBody *responseBody
+		raw          bool
+		err          error
 	)
 
-	if it.ResponseSanitizer != nil {
-		it.ResponseSanitizer(response)
-	}
+	if response.Body != nil {
+		responseBody, err = it.readResponseBody(response)
+		// if could not get body from response, no add to allure
+		if err != nil {
+			return err
+		}
 
-	headers, _ := utils.ToJSON(response.Header)
-	if headers != "" {
-		t.WithNewParameters("response_headers", headers)
-	}
+		body = responseBody.plain
 
-	t.WithNewParameters("response_code", fmt.Sprint(response.StatusCode))
-	it.Info(t, "[Response] Status: "+response.Status)
+		// Body, which could not be decoded, is attached as is
+		if responseBody.decodeErr != nil {
+			it.Error(t, "Could not decode response body, raw body is attached. error %v", responseBody.decodeErr)
 
-	if response.Body == nil {
-		return nil
+			body, raw = responseBody.Bytes(), true
+		}
 	}
 
-	saveBody, response.Body, err = utils.DrainBody(response.Body)
-	// if could not get body from response, no add to allure
-	if err != nil {
-		return err
+	// Sanitizer and redaction policy are applied to copy, so asserts get real response
+	reportHeaders, body := it.responseForReport(response, body)
+
+	headers, _ := utils.ToJSON(reportHeaders)
+	if headers != "" {
+		t.WithNewParameters("response_headers", it.redactor.String(headers))
 	}
 
-	body, err := utils.GetBody(saveBody)
-	// if could not get body from response, no add to allure
-	if err != nil {
-		return err
+	t.WithNewParameters("response_code", fmt.Sprint(response.StatusCode), "protocol", response.Proto)
+
+	if response.TLS != nil {
+		t.WithNewParameters("tls_version", tls.VersionName(response.TLS.Version))
 	}
+	it.Info(t, "[Response] Status: "+response.Status)
 
 	// if body is empty - skip
 	if len(body) == 0 {
@@ -267,33 +343,40 @@ func (it *Test) addInformationResponse(t T, response *http.Response) error {
 		}
 	}
 
-	if responseType == allure.JSON {
-		body, _ = utils.PrettyJSON(body)
+	if raw {
+		responseType = allure.MimeType("application/octet-stream")
+	}
+
+	if responseType == allure.JSON && !responseBody.Truncated() {
+		if pretty, prettyErr := utils.PrettyJSON(body); prettyErr == nil {
+			body = pretty
+		}
+	}
+
+	body, truncated := it.truncateForReport(body)
+
+	if responseBody.Truncated() {
+		body = append(body[:len(body):len(body)], fmt.Sprintf("\n\n... response body is larger than %v bytes, rest is not shown", it.maxBodySize)...)
+		truncated = true
+	}
+
+	// truncated body is not valid JSON anymore
+	if truncated {
+		responseType = allure.Text
 	}
 
-	t.WithAttachments(allure.NewAttachment("response", responseType, body))
+	t.WithAttachments(allure.NewAttachment("response", responseType, it.redactor.Bytes(body)))
 
 	return nil
 }
 
 func (it *Test) createTitle(try, countRepeat int, req *http.Request) string {
-	toProcess := req
-
-	// We have to execute sanitizer hook because
+	// We have to execute sanitizer hook and redaction policy because
 	// we need to log it and it can contain sensitive data
-	if it.RequestSanitizer != nil {
-		clone, err := copyRequest(req.Context(), req)
+	// body is not needed for title, so it's not copied
+	toProcess, _ := it.requestForReport(req, nil)
 
-		// ignore error, because we want to log request
-		// and it does not matter if we can copy request
-		if err == nil {
-			it.RequestSanitizer(clone)
-
-			toProcess = clone
-		}
-	}
-
-	title := toProcess.Method + " " + toProcess.URL.String()
+	title := it.redactor.String(toProcess.Method + " " + toProcess.URL.String())
 
 	if countRepeat == 1 {
 		return title
@@ -301,3 +384,23 @@ func (it *Test) createTitle(try, countRepeat int, req *http.Request) string {
 
 	return fmt.Sprintf("[%v/%v] %v", try, countRepeat, title)
 }
+
+// decodeBody returns plaintext body by Content-Encoding header.
+// Go http client decodes gzip automatically and removes header,
+// but if Accept-Encoding was set manually, body stays encoded.
+// Body with unsupported encoding is returned as is.
+// If limit > 0, decoded body is truncated to limit and second value is true.
+func decodeBody(header http.Header, body []byte, limit int64) ([]byte, bool, error) {
+	contentEncoding := header.Get("Content-Encoding")
+
+	if contentEncoding == "" || len(body) == 0 {
+		return body, false, nil
+	}
+
+	decoded, truncated, err := utils.DecodeBodyLimit(contentEncoding, body, limit)
+	if errors.Is(err, utils.ErrUnsupportedEncoding) {
+		return body, false, nil
+	}
+
+	return decoded, truncated, err
+}
@@ -0,0 +1,262 @@
+package cute
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+)
+
+// Environment variables for select tests at runtime
+const (
+	// EnvTags is a boolean expression of tags, for example smoke,!slow or (users|orders)&!slow
+	EnvTags = "CUTE_TAGS"
+	// EnvMinSeverity is a minimal severity of test, for example critical
+	EnvMinSeverity = "CUTE_MIN_SEVERITY"
+	// EnvLayers is a comma separated list of layers, for example api,e2e
+	EnvLayers = "CUTE_LAYERS"
+)
+
+var severityOrder = map[allure.SeverityType]int{
+	allure.TRIVIAL:  1,
+	allure.MINOR:    2,
+	allure.NORMAL:   3,
+	allure.CRITICAL: 4,
+	allure.BLOCKER:  5,
+}
+
+// selection is a filter of tests by tags, severity and layer
+type selection struct {
+	tags        string
+	minSeverity string
+	layers      string
+}
+
+// skipReason returns reason for skip test, empty reason means that test is selected
+// Environment variables override options of HTTPTestMaker.
+func (s selection) skipReason(labels *allureLabels) (string, error) {
+	tags := lookupEnv(EnvTags, s.tags)
+	if tags != "" {
+		expr, err := parseTagExpression(tags)
+		if err != nil {
+			return "", fmt.Errorf("could not parse %v=%v error: '%s'", EnvTags, tags, err)
+		}
+
+		testTags := labels.allTags()
+		if !expr(testTags) {
+			return fmt.Sprintf("skipped by %v=%v: tags [%v] don't match", EnvTags, tags, strings.Join(sortedKeys(testTags), ", ")), nil
+		}
+	}
+
+	minSeverity := lookupEnv(EnvMinSeverity, s.minSeverity)
+	if minSeverity != "" {
+		minOrder, ok := severityOrder[allure.SeverityType(strings.ToLower(minSeverity))]
+		if !ok {
+			return "", fmt.Errorf("could not parse %v=%v error: 'unknown severity, use blocker, critical, normal, minor or trivial'", EnvMinSeverity, minSeverity)
+		}
+
+		severity := labels.severity
+		if severity == "" {
+			severity = allure.NORMAL
+		}
+
+		if severityOrder[severity] < minOrder {
+			return fmt.Sprintf("skipped by %v=%v: severity %v is lower", EnvMinSeverity, minSeverity, severity), nil
+		}
+	}
+
+	layers := lookupEnv(EnvLayers, s.layers)
+	if layers != "" {
+		for _, layer := range strings.Split(layers, ",") {
+			if strings.EqualFold(strings.TrimSpace(layer), labels.layer) {
+				return "", nil
+			}
+		}
+
+		return fmt.Sprintf("skipped by %v=%v: layer %q doesn't match", EnvLayers, layers, labels.layer), nil
+	}
+
+	return "", nil
+}
+
+func lookupEnv(name, def string) string {
+	if value, ok := os.LookupEnv(name); ok {
+		return value
+	}
+
+	return def
+}
+
+// allTags returns tags of test in lower case
+func (l *allureLabels) allTags() map[string]bool {
+	res := make(map[string]bool, len(l.tags)+1)
+
+	if l.tag != "" {
+		res[strings.ToLower(l.tag)] = true
+	}
+
+	for _, tag := range l.tags {
+		res[strings.ToLower(tag)] = true
+	}
+
+	return res
+}
+
+func sortedKeys(m map[string]bool) []string {
+	res := make([]string, 0, len(m))
+	for key := range m {
+		res = append(res, key)
+	}
+
+	sort.Strings(res)
+
+	return res
+}
+
+// tagExpression returns true, if tags match expression
+type tagExpression func(tags map[string]bool) bool
+
+// parseTagExpression parses boolean expression of tags
+// Operators: ! - not, , or & - and, | - or. Parentheses group expressions, and has higher priority than or.
+func parseTagExpression(s string) (tagExpression, error) {
+	p := &tagParser{tokens: tokenizeTags(s)}
+
+	expr, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.pos != len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+
+	return expr, nil
+}
+
+func tokenizeTags(s string) []string {
+	var (
+		tokens = make([]string, 0)
+		tag    strings.Builder
+	)
+
+	flush := func() {
+		if tag.Len() != 0 {
+			tokens = append(tokens, strings.ToLower(tag.String()))
+			tag.Reset()
+		}
+	}
+
+	for _, r := range s {
+		switch {
+		case strings.ContainsRune("!,&|()", r):
+			flush()
+
+			tokens = append(tokens, string(r))
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			tag.WriteRune(r)
+		}
+	}
+
+	flush()
+
+	return tokens
+}
+
+type tagParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *tagParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+
+	return ""
+}
+
+func (p *tagParser) or() (tagExpression, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek() == "|" {
+		p.pos++
+
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+
+		l := left
+		left = func(tags map[string]bool) bool { return l(tags) || right(tags) }
+	}
+
+	return left, nil
+}
+
+func (p *tagParser) and() (tagExpression, error) {
+	left, err := p.not()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek() == "," || p.peek() == "&" {
+		p.pos++
+
+		right, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+
+		l := left
+		left = func(tags map[string]bool) bool { return l(tags) && right(tags) }
+	}
+
+	return left, nil
+}
+
+func (p *tagParser) not() (tagExpression, error) {
+	token := p.peek()
+
+	switch token {
+	case "!":
+		p.pos++
+
+		expr, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+
+		return func(tags map[string]bool) bool { return !expr(tags) }, nil
+	case "(":
+		p.pos++
+
+		expr, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("expected )")
+		}
+
+		p.pos++
+
+		return expr, nil
+	case "":
+		return nil, fmt.Errorf("unexpected end of expression")
+	case ",", "&", "|", ")":
+		return nil, fmt.Errorf("expected tag, got %q", token)
+	}
+
+	p.pos++
+
+	return func(tags map[string]bool) bool { return tags[token] }, nil
+}
@@ -0,0 +1,103 @@
+package cute
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTagExpression(t *testing.T) {
+	tags := map[string]bool{"smoke": true, "users": true}
+
+	for expression, expected := range map[string]bool{
+		"smoke":                   true,
+		"Smoke":                   true,
+		"slow":                    false,
+		"smoke,!slow":             true,
+		"smoke & users":           true,
+		"smoke,slow":              false,
+		"slow|users":              true,
+		"!(slow|orders)":          true,
+		"(orders|users)&!smoke":   false,
+		"orders|users,smoke":      true,
+		"!!smoke":                 true,
+		"regress|(smoke,!users)":  false,
+		"regress | (smoke,users)": true,
+	} {
+		expr, err := parseTagExpression(expression)
+		require.NoError(t, err, expression)
+		require.Equal(t, expected, expr(tags), expression)
+	}
+
+	for _, expression := range []string{"", "smoke,", "(smoke", "smoke)", "!", "smoke||users"} {
+		_, err := parseTagExpression(expression)
+		require.Error(t, err, expression)
+	}
+}
+
+func TestSelectionSkipReason(t *testing.T) {
+	labels := &allureLabels{tags: []string{"users", "slow"}, severity: allure.MINOR, layer: "api"}
+
+	reason, err := selection{}.skipReason(labels)
+	require.NoError(t, err)
+	require.Empty(t, reason)
+
+	reason, err = selection{tags: "users,!slow"}.skipReason(labels)
+	require.NoError(t, err)
+	require.Equal(t, "skipped by CUTE_TAGS=users,!slow: tags [slow, users] don't match", reason)
+
+	reason, err = selection{minSeverity: "critical"}.skipReason(labels)
+	require.NoError(t, err)
+	require.Equal(t, "skipped by CUTE_MIN_SEVERITY=critical: severity minor is lower", reason)
+
+	reason, err = selection{minSeverity: "normal"}.skipReason(&allureLabels{})
+	require.NoError(t, err)
+	require.Empty(t, reason)
+
+	reason, err = selection{layers: "e2e, API"}.skipReason(labels)
+	require.NoError(t, err)
+	require.Empty(t, reason)
+
+	reason, err = selection{layers: "e2e"}.skipReason(labels)
+	require.NoError(t, err)
+	require.Equal(t, `skipped by CUTE_LAYERS=e2e: layer "api" doesn't match`, reason)
+
+	_, err = selection{minSeverity: "high"}.skipReason(labels)
+	require.ErrorContains(t, err, "could not parse CUTE_MIN_SEVERITY=high")
+
+	t.Setenv(EnvTags, "slow")
+
+	reason, err = selection{tags: "smoke"}.skipReason(labels)
+	require.NoError(t, err)
+	require.Empty(t, reason)
+}
+
+func TestExecuteTestSkipNotSelected(t *testing.T) {
+	var executed bool
+
+	maker := NewHTTPTestMaker(
+		WithTagFilter("smoke"),
+		WithMiddlewareBefore(func(_ *http.Request) error {
+			executed = true
+
+			return nil
+		}),
+	)
+
+	t.Run("slow", func(t *testing.T) {
+		maker.NewTestBuilder().
+			Title("slow").
+			Tags("slow").
+			Create().
+			RequestBuilder(
+				WithURI("http://localhost:1"),
+				WithMethod(http.MethodGet),
+			).
+			ExecuteTest(context.Background(), t)
+	})
+
+	require.False(t, executed)
+}
@@ -13,46 +13,36 @@ func (it *Test) executeWithStep(t internalT, stepName string, execute func(t T)
 		errs []error
 	)
 
-	// Add attempt indication in Allure if more than 1 attempt
-	if it.Retry.MaxAttempts != 1 {
-		stepName = fmt.Sprintf("[Attempt #%d] %v", it.Retry.currentCount, stepName)
-	}
-	t.WithNewStep(stepName, func(stepCtx provider.StepCtx) {
+	t.WithNewStep(it.stepName(stepName), func(stepCtx provider.StepCtx) {
 		errs = execute(stepCtx)
-		processStepErrors(stepCtx, errs)
+		it.processStepErrors(stepCtx, errs)
 	})
 
 	return errs
 }
 
-func processStepErrors(stepCtx provider.StepCtx, errs []error) {
-	var (
-		step     = stepCtx.CurrentStep()
-		statuses = make([]allure.Status, 0)
-	)
+// stepName adds attempt indication in Allure if more than 1 attempt
+func (it *Test) stepName(name string) string {
+	if it.Retry.MaxAttempts != 1 {
+		return fmt.Sprintf("[Attempt #%d] %v", it.Retry.currentCount, name)
+	}
+
+	return name
+}
+
+func (it *Test) processStepErrors(stepCtx provider.StepCtx, errs []error) {
+	step := stepCtx.CurrentStep()
 
 	if len(errs) == 0 {
 		return
 	}
 
 	for _, err := range errs {
-		currentStatus := allure.Failed
+		currentStatus := errorStatus(err)
 		currentStep := step
 
-		if tErr, ok := err.(errors.OptionalError); ok {
-			if tErr.IsOptional() {
-				currentStatus = allure.Skipped
-			}
-		}
-
-		if tErr, ok := err.(errors.BrokenError); ok {
-			if tErr.IsBroken() {
-				currentStatus = allure.Broken
-			}
-		}
-
 		if tErr, ok := err.(errors.WithNameError); ok {
-			currentStep = allure.NewSimpleStep(tErr.GetName())
+			currentStep = allure.NewSimpleStep(it.redactor.String(tErr.GetName()))
 			currentStep.Status = currentStatus
 			currentStep.WithParent(step)
 		}
@@ -63,7 +53,7 @@ func processStepErrors(stepCtx provider.StepCtx, errs []error) {
 					continue
 				}
 
-				currentStep.WithNewParameters(k, v)
+				currentStep.WithNewParameters(k, it.redactor.Value(v))
 			}
 		}
 
@@ -73,21 +63,41 @@ func processStepErrors(stepCtx provider.StepCtx, errs []error) {
 					continue
 				}
 
-				currentStep.WithAttachments(allure.NewAttachment(v.Name, allure.MimeType(v.MimeType), v.Content))
+				currentStep.WithAttachments(allure.NewAttachment(v.Name, allure.MimeType(v.MimeType), it.redactor.Bytes(v.Content)))
 			}
 		}
 
-		statuses = append(statuses, currentStatus)
+		currentStep.WithAttachments(allure.NewAttachment("Error", allure.Text, []byte(it.redactor.String(err.Error()))))
+	}
+
+	step.Status = stepStatus(errs)
+}
+
+// errorStatus returns allure status of error: skipped for optional error, broken for broken error, otherwise failed
+func errorStatus(err error) allure.Status {
+	if tErr, ok := err.(errors.BrokenError); ok && tErr.IsBroken() {
+		return allure.Broken
+	}
 
-		currentStep.WithAttachments(allure.NewAttachment("Error", allure.Text, []byte(err.Error())))
+	if tErr, ok := err.(errors.OptionalError); ok && tErr.IsOptional() {
+		return allure.Skipped
 	}
 
-	// If one error was not optional, parent step should be failed
-	for _, status := range statuses {
-		step.Status = status
+	return allure.Failed
+}
+
+// stepStatus returns status of step with errors
+// If one error was not optional, parent step should be failed
+func stepStatus(errs []error) allure.Status {
+	var status allure.Status
+
+	for _, err := range errs {
+		status = errorStatus(err)
 
 		if status == allure.Failed {
 			break
 		}
 	}
+
+	return status
 }
@@ -0,0 +1,169 @@
+package cute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sync"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+)
+
+// BeforeAll is a function, which is executed once before first test of HTTPTestMaker
+// For example, it seeds data or creates shared fixtures.
+type BeforeAll func(ctx context.Context) error
+
+// AfterAll is a function, which is executed once by HTTPTestMaker.Close after all tests of HTTPTestMaker
+type AfterAll func(ctx context.Context) error
+
+var errorSuiteClosed = errors.New("could not start test, HTTPTestMaker is already closed")
+
+// suite runs BeforeAll and AfterAll hooks of HTTPTestMaker
+type suite struct {
+	beforeAll []BeforeAll
+	afterAll  []AfterAll
+
+	startOnce sync.Once
+	closeOnce sync.Once
+	// running are tests, which are executed now, Close waits them
+	running sync.WaitGroup
+
+	mu        sync.Mutex
+	started   bool
+	closed    bool
+	err       error
+	container *allure.Container
+}
+
+func newSuite(beforeAll []BeforeAll, afterAll []AfterAll) *suite {
+	return &suite{
+		beforeAll: beforeAll,
+		afterAll:  afterAll,
+		container: allure.NewContainer(),
+	}
+}
+
+// start runs BeforeAll hooks once and registers test in suite, done has to be called, when test is finished.
+// Returned error is an error of BeforeAll hooks, it's the same for all tests.
+// Test could not be started after close, then done is a no-op.
+func (s *suite) start(ctx context.Context, t internalT) (done func(), err error) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+
+		return func() {}, errorSuiteClosed
+	}
+
+	// Test is added under lock, so close doesn't wait while new tests are added
+	s.running.Add(1)
+	s.mu.Unlock()
+
+	s.startOnce.Do(func() {
+		s.mu.Lock()
+		s.started = true
+		s.mu.Unlock()
+
+		s.container.Begin()
+
+		// Hooks are not canceled with context of first test
+		ctx = context.WithoutCancel(ctx)
+
+		for i, hook := range s.beforeAll {
+			step, err := runSuiteHook(ctx, fmt.Sprintf("BeforeAll %v", i+1), hook)
+
+			s.mu.Lock()
+			s.container.Befores = append(s.container.Befores, step)
+			s.mu.Unlock()
+
+			if err != nil {
+				s.err = fmt.Errorf("before all %v is failed error: '%s'", step.Name, err)
+
+				break
+			}
+		}
+	})
+
+	s.addChild(t)
+
+	return s.running.Done, s.err
+}
+
+// addChild adds allure result of test to container, for example result of table subtest
+func (s *suite) addChild(t interface{}) {
+	if result, ok := t.(interface{ GetResult() *allure.Result }); ok && result.GetResult() != nil {
+		s.mu.Lock()
+		s.container.AddChild(result.GetResult().UUID)
+		s.mu.Unlock()
+	}
+}
+
+// close waits running tests, runs AfterAll hooks once and writes allure container
+func (s *suite) close(ctx context.Context) error {
+	var errs []error
+
+	s.closeOnce.Do(func() {
+		// New tests are rejected, then running tests are waited
+		s.mu.Lock()
+		s.closed = true
+		s.mu.Unlock()
+
+		s.running.Wait()
+
+		s.mu.Lock()
+		started := s.started
+		s.mu.Unlock()
+
+		// Nothing to tear down, if no tests were executed
+		if !started {
+			return
+		}
+
+		for i, hook := range s.afterAll {
+			step, err := runSuiteHook(ctx, fmt.Sprintf("AfterAll %v", i+1), hook)
+
+			s.container.Afters = append(s.container.Afters, step)
+
+			if err != nil {
+				errs = append(errs, fmt.Errorf("after all %v is failed error: '%s'", step.Name, err))
+			}
+		}
+
+		if err := s.container.Done(); err != nil {
+			errs = append(errs, fmt.Errorf("could not write allure container error: '%s'", err))
+		}
+	})
+
+	return errors.Join(errs...)
+}
+
+// runSuiteHook runs hook and returns allure step with result of hook
+// Panic of hook is returned as error.
+func runSuiteHook(ctx context.Context, name string, hook func(ctx context.Context) error) (step *allure.Step, err error) {
+	step = allure.NewSimpleStep(name, allure.NewParameter("function", hookName(hook)))
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+
+		step.Finish()
+
+		if err != nil {
+			step.Broken()
+			step.WithNewParameters("error", err.Error())
+		}
+	}()
+
+	return step, hook(ctx)
+}
+
+// hookName returns name of function of hook
+func hookName(hook interface{}) string {
+	if f := runtime.FuncForPC(reflect.ValueOf(hook).Pointer()); f != nil {
+		return f.Name()
+	}
+
+	return "unknown"
+}
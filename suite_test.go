@@ -0,0 +1,202 @@
+package cute
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuiteHooks(t *testing.T) {
+	srv, methods := newFinallyServer(t)
+
+	var before, after atomic.Int32
+
+	maker := NewHTTPTestMaker(
+		WithBeforeAll(func(_ context.Context) error {
+			before.Add(1)
+
+			return nil
+		}),
+		WithAfterAll(func(_ context.Context) error {
+			after.Add(1)
+
+			return nil
+		}),
+	)
+
+	t.Run("group", func(t *testing.T) {
+		for _, name := range []string{"first", "second", "third"} {
+			name := name
+
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				maker.NewTestBuilder().
+					Title(name).
+					Create().
+					RequestBuilder(WithURI(srv.URL), WithMethod(http.MethodGet)).
+					ExpectStatus(http.StatusOK).
+					ExecuteTest(context.Background(), t)
+			})
+		}
+	})
+
+	require.NoError(t, maker.Close())
+	require.NoError(t, maker.Close())
+
+	require.Equal(t, int32(1), before.Load())
+	require.Equal(t, int32(1), after.Load())
+	require.Len(t, *methods, 3)
+
+	container := maker.suite.container
+	require.Len(t, container.Children, 3)
+	require.Len(t, container.Befores, 1)
+	require.Equal(t, "BeforeAll 1", container.Befores[0].Name)
+	require.Equal(t, allure.Passed, container.Afters[0].Status)
+}
+
+func TestSuiteBeforeAllError(t *testing.T) {
+	srv, methods := newFinallyServer(t)
+
+	maker := NewHTTPTestMaker(WithBeforeAll(
+		func(_ context.Context) error {
+			return errors.New("could not seed")
+		},
+		func(_ context.Context) error {
+			panic("must not be called")
+		},
+	))
+
+	for i := 0; i < 2; i++ {
+		builder := maker.NewTestBuilder().
+			Title("broken").
+			Create().
+			RequestBuilder(WithURI(srv.URL), WithMethod(http.MethodGet))
+
+		result, failed, recovered := executeRecorded(t, builder)
+
+		require.Nil(t, recovered)
+		require.True(t, failed)
+		require.Equal(t, allure.Broken, result.Status)
+		require.Contains(t, result.StatusDetails.Message, "before all BeforeAll 1 is failed error: 'could not seed'")
+	}
+
+	require.Empty(t, *methods)
+
+	require.NoError(t, maker.Close())
+	require.Len(t, maker.suite.container.Befores, 1)
+	require.Equal(t, allure.Broken, maker.suite.container.Befores[0].Status)
+}
+
+func TestSuiteAfterAll(t *testing.T) {
+	var called bool
+
+	maker := NewHTTPTestMaker(WithAfterAll(func(_ context.Context) error {
+		called = true
+
+		panic("could not clean")
+	}))
+
+	// AfterAll is not called, if tests were not executed
+	require.NoError(t, maker.Close())
+	require.False(t, called)
+
+	maker = NewHTTPTestMaker(WithAfterAll(func(_ context.Context) error {
+		called = true
+
+		panic("could not clean")
+	}))
+
+	done, err := maker.suite.start(context.Background(), nil)
+	require.NoError(t, err)
+	done()
+
+	require.ErrorContains(t, maker.Close(), "after all AfterAll 1 is failed error: 'panic: could not clean'")
+	require.True(t, called)
+}
+
+func TestSuiteClosed(t *testing.T) {
+	srv, methods := newFinallyServer(t)
+
+	maker := NewHTTPTestMaker(WithBeforeAll(func(_ context.Context) error {
+		return nil
+	}))
+
+	require.NoError(t, maker.Close())
+
+	builder := maker.NewTestBuilder().
+		Title("closed").
+		Create().
+		RequestBuilder(WithURI(srv.URL), WithMethod(http.MethodGet))
+
+	result, failed, recovered := executeRecorded(t, builder)
+
+	require.Nil(t, recovered)
+	require.True(t, failed)
+	require.Equal(t, allure.Broken, result.Status)
+	require.Contains(t, result.StatusDetails.Message, "HTTPTestMaker is already closed")
+	require.Empty(t, *methods)
+}
+
+func TestSuiteTableTestChildren(t *testing.T) {
+	srv, methods := newFinallyServer(t)
+
+	maker := NewHTTPTestMaker(WithAfterAll(func(_ context.Context) error {
+		return nil
+	}))
+
+	maker.NewTestBuilder().
+		Title("table").
+		CreateTableTest().
+		PutTests(
+			&Test{Name: "first", Request: &Request{Builders: []RequestBuilder{WithURI(srv.URL), WithMethod(http.MethodGet)}}},
+			&Test{Name: "second", Request: &Request{Builders: []RequestBuilder{WithURI(srv.URL), WithMethod(http.MethodGet)}}},
+		).
+		ExecuteTest(context.Background(), t)
+
+	require.NoError(t, maker.Close())
+	require.Len(t, *methods, 2)
+
+	// Result of table test and results of its subtests
+	require.Len(t, maker.suite.container.Children, 3)
+}
+
+func TestSuiteWithoutHooks(t *testing.T) {
+	maker := NewHTTPTestMaker()
+
+	require.Nil(t, maker.suite)
+	require.NoError(t, maker.Close())
+}
+
+func TestSuiteNotClosed(t *testing.T) {
+	srv, methods := newFinallyServer(t)
+
+	var after atomic.Int32
+
+	maker := NewHTTPTestMaker(WithAfterAll(func(_ context.Context) error {
+		after.Add(1)
+
+		return nil
+	}))
+
+	builder := maker.NewTestBuilder().
+		Title("not closed").
+		Create().
+		RequestBuilder(WithURI(srv.URL), WithMethod(http.MethodGet))
+
+	_, failed, _ := executeRecorded(t, builder)
+	require.False(t, failed)
+	require.Len(t, *methods, 1)
+
+	// AfterAll is run only by Close
+	require.Zero(t, after.Load())
+	require.Empty(t, maker.suite.container.Afters)
+
+	require.NoError(t, maker.Close())
+	require.Equal(t, int32(1), after.Load())
+}
@@ -10,7 +10,9 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strings"
 	"testing"
+	"text/template"
 	"time"
 
 	"github.com/ozontech/allure-go/pkg/framework/provider"
@@ -45,6 +47,28 @@ type Test struct {
 	jsonMarshaler  JSONMarshaler
 	lastRequestURL string
 
+	// templateFuncs are functions of body templates, it's own copy of test
+	templateFuncs template.FuncMap
+
+	// maxBodySize is a limit of response body for asserts, 0 means without limit
+	maxBodySize int64
+	// maxAttachmentSize is a limit of body in allure report, 0 means without limit
+	maxAttachmentSize int
+	// redactor hides sensitive data in logs and allure report
+	redactor *redactor
+	// jsonSchemaRegistry resolves and caches JSON schemas, default registry is used if it's nil
+	jsonSchemaRegistry *JSONSchemaRegistry
+	// baseURL resolves relative URIs of requests
+	baseURL *url.URL
+	// defaultHeaders are added to request, if request has no header with the same name
+	defaultHeaders map[string]string
+	// coverage records executed operations of OpenAPI document
+	coverage *Coverage
+	// limiter limits rate and count of in-flight requests per host
+	limiter *limiter
+	// finally is true for cleanup test, which is executed even if previous tests are failed
+	finally bool
+
 	Name     string
 	Parallel bool
 	Retry    *Retry
@@ -77,6 +101,14 @@ type Request struct {
 	Base     *http.Request
 	Builders []RequestBuilder
 	Retry    *RequestRetryPolitic
+	// Race is a count of identical requests, which are sent at the same time, see RequestRace
+	Race int
+	// Idempotency replays request with the same idempotency key, see RequestIdempotency
+	Idempotency *Idempotency
+	// Pagination follows pages of list request until they are exhausted, see RequestPagination
+	Pagination *Pagination
+	// Poll sends request repeatedly until response is terminal, see PollUntil
+	Poll *Poll
 }
 
 // RequestRetryPolitic is struct for repeat politic
@@ -127,6 +159,11 @@ type Expect struct {
 	AssertBodyT     []AssertBodyT
 	AssertHeadersT  []AssertHeadersT
 	AssertResponseT []AssertResponseT
+
+	// AssertRace validates all responses of race request
+	AssertRace []AssertRace
+	// AssertItems validates items of all pages of paginated request
+	AssertItems []AssertItems
 }
 
 // ExpectJSONSchema is structs with JSON politics for response
@@ -366,8 +403,8 @@ func (it *Test) processTestErrors(t internalT, errs []error) ResultState {
 		}
 
 		if tErr, ok := err.(cuteErrors.WithFields); ok {
-			actual := tErr.GetFields()[cuteErrors.ActualField]
-			expected := tErr.GetFields()[cuteErrors.ExpectedField]
+			actual := it.redactor.Value(tErr.GetFields()[cuteErrors.ActualField])
+			expected := it.redactor.Value(tErr.GetFields()[cuteErrors.ExpectedField])
 
 			if actual != nil || expected != nil {
 				message = fmt.Sprintf("%s\nActual %v\nExpected %v", message, actual, expected)
@@ -399,6 +436,11 @@ func (it *Test) startTest(ctx context.Context, t internalT) (*http.Response, []e
 		it.Expect.ExecuteTime = defaultExecuteTestTime
 	}
 
+	// Polling could take longer than execute timeout of one request
+	if it.Request.Poll != nil && it.Request.Poll.Timeout > it.Expect.ExecuteTime {
+		it.Expect.ExecuteTime = it.Request.Poll.Timeout
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, it.Expect.ExecuteTime)
 	defer cancel()
 
@@ -413,6 +455,21 @@ func (it *Test) startTest(ctx context.Context, t internalT) (*http.Response, []e
 		return nil, errs
 	}
 
+	// Race request is sent concurrently, every response is validated and then all responses together
+	if it.Request.Race > 1 || it.Request.Idempotency != nil {
+		return it.startRaceTest(t, req)
+	}
+
+	// Paginated request follows pages, every page is validated and then items of all pages together
+	if it.Request.Pagination != nil {
+		return it.startPaginatedTest(t, req)
+	}
+
+	// Polled request is repeated until response is terminal, only the terminal response is validated
+	if it.Request.Poll != nil {
+		return it.startPollTest(t, req)
+	}
+
 	it.Info(t, "Start make request")
 
 	// Make request
@@ -521,11 +578,17 @@ func (it *Test) buildRequest(ctx context.Context) (*http.Request, error) {
 		o = newRequestOptions()
 	)
 
+	o.templateFuncs = it.templateFuncs
+
 	// Set builder parameters
 	for _, builder := range it.Request.Builders {
 		builder(o)
 	}
 
+	if o.err != nil {
+		return nil, o.err
+	}
+
 	reqURL := o.url
 
 	if reqURL == nil {
@@ -535,6 +598,8 @@ func (it *Test) buildRequest(ctx context.Context) (*http.Request, error) {
 		}
 	}
 
+	reqURL = resolveURL(it.baseURL, reqURL)
+
 	// Set query parameters
 	query := reqURL.Query()
 	for key, values := range o.query {
@@ -558,55 +623,149 @@ func (it *Test) buildRequest(ctx context.Context) (*http.Request, error) {
 	// Set multipart
 	if len(o.fileForms) != 0 || len(o.forms) != 0 {
 		var (
-			buffer = new(bytes.Buffer)
-			mp     = multipart.NewWriter(buffer)
+			boundary    = multipart.NewWriter(io.Discard).Boundary()
+			contentType = "multipart/form-data; boundary=" + boundary
 		)
 
-		// set file forms
-		for fieldName, file := range o.fileForms {
-			err = createFormFile(mp, fieldName, file)
+		streamed, err := hasFilesOnDisk(o.fileForms)
+		if err != nil {
+			return nil, err
+		}
+
+		if streamed {
+			// Files from disk are streamed on every attempt, instead of reading them to memory
+			body := utils.NewStreamBody(func() (io.ReadCloser, error) {
+				pr, pw := io.Pipe()
+
+				go func() {
+					pw.CloseWithError(writeMultipart(pw, boundary, o))
+				}()
+
+				return pr, nil
+			})
+
+			req, err = http.NewRequestWithContext(ctx, o.method, reqURL.String(), body)
 			if err != nil {
 				return nil, err
 			}
-		}
 
-		// set forms
-		for fieldName, fieldBody := range o.forms {
-			err = createFormField(mp, fieldName, fieldBody)
-			if err != nil {
+			req.GetBody = body.Reopen
+		} else {
+			buffer := new(bytes.Buffer)
+
+			if err = writeMultipart(buffer, boundary, o); err != nil {
 				return nil, err
 			}
-		}
 
-		if err = mp.Close(); err != nil {
-			return nil, err
+			req, err = http.NewRequestWithContext(ctx, o.method, reqURL.String(), buffer)
+			if err != nil {
+				return nil, err
+			}
 		}
 
-		req, err = http.NewRequestWithContext(ctx, o.method, reqURL.String(), buffer)
-		if err != nil {
-			return nil, err
+		req.Header.Add("Content-Type", contentType)
+	} else {
+		if o.contentEncoding != "" {
+			body, err = utils.EncodeBody(o.contentEncoding, body)
+			if err != nil {
+				return nil, err
+			}
 		}
 
-		req.Header.Add("Content-Type", mp.FormDataContentType())
-	} else {
 		req, err = http.NewRequestWithContext(ctx, o.method, reqURL.String(), io.NopCloser(bytes.NewReader(body)))
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	if o.contentEncoding != "" {
+		req.Header.Set("Content-Encoding", o.contentEncoding)
+	}
+
 	// Set headers
 	for nameHeader, valuesHeader := range o.headers {
 		req.Header[nameHeader] = valuesHeader
 	}
 
+	// Set default headers of profile
+	for nameHeader, valueHeader := range it.defaultHeaders {
+		if !hasHeader(req.Header, nameHeader) {
+			req.Header.Set(nameHeader, valueHeader)
+		}
+	}
+
 	return req, nil
 }
 
+// hasHeader checks header case-insensitively, because headers of builders are not canonicalized
+func hasHeader(headers http.Header, name string) bool {
+	for key := range headers {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasFilesOnDisk checks, that files for forms exist
+// Returns true, if any file has to be read from disk
+func hasFilesOnDisk(fileForms map[string]*File) (bool, error) {
+	onDisk := false
+
+	for fieldName, file := range fileForms {
+		if len(file.Path) == 0 {
+			continue
+		}
+
+		if _, err := os.Stat(file.Path); err != nil {
+			return false, fmt.Errorf("error when reading %v file form field, %w", fieldName, err)
+		}
+
+		onDisk = true
+	}
+
+	return onDisk, nil
+}
+
+// writeMultipart writes multipart form to w, body is compressed, if content encoding is set
+func writeMultipart(w io.Writer, boundary string, o *requestOptions) error {
+	encoder, err := utils.NewEncoder(o.contentEncoding, w)
+	if err != nil {
+		return err
+	}
+
+	mp := multipart.NewWriter(encoder)
+
+	if err = mp.SetBoundary(boundary); err != nil {
+		return err
+	}
+
+	// set file forms
+	for fieldName, file := range o.fileForms {
+		if err = createFormFile(mp, fieldName, file); err != nil {
+			return err
+		}
+	}
+
+	// set forms
+	for fieldName, fieldBody := range o.forms {
+		if err = createFormField(mp, fieldName, fieldBody); err != nil {
+			return err
+		}
+	}
+
+	if err = mp.Close(); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
 func createFormFile(mp *multipart.Writer, fieldName string, file *File) error {
 	var (
-		data = file.Body
-		name = file.Name
+		data io.Reader = bytes.NewReader(file.Body)
+		name           = file.Name
 	)
 
 	// read file, if path is not empty
@@ -615,12 +774,9 @@ func createFormFile(mp *multipart.Writer, fieldName string, file *File) error {
 		if err != nil {
 			return err
 		}
+		defer f.Close()
 
-		data, err = io.ReadAll(f)
-		if err != nil {
-			return err
-		}
-
+		data = f
 		name = f.Name()
 	}
 
@@ -629,7 +785,7 @@ func createFormFile(mp *multipart.Writer, fieldName string, file *File) error {
 		return fmt.Errorf("error when creating %v file form field, %w", fieldName, err)
 	}
 
-	_, err = field.Write(data)
+	_, err = io.Copy(field, data)
 	if err != nil {
 		return fmt.Errorf("error when writing %v file form field, %w", fieldName, err)
 	}
@@ -660,14 +816,44 @@ func (it *Test) validateRequest(req *http.Request) error {
 		return errorRequestMethodEmpty
 	}
 
+	return it.Request.validateModes()
+}
+
+// validateModes returns error, if request modes are combined, because only one of them could be executed.
+// Request retry is applied to every page of pagination, but other modes send requests by themselves.
+func (r *Request) validateModes() error {
+	modes := make([]string, 0)
+
+	if r.Race > 1 {
+		modes = append(modes, "RequestRace")
+	}
+
+	if r.Idempotency != nil {
+		modes = append(modes, "RequestIdempotency")
+	}
+
+	if r.Pagination != nil {
+		modes = append(modes, "RequestPagination")
+	}
+
+	if r.Poll != nil {
+		modes = append(modes, "PollUntil")
+	}
+
+	if len(modes) > 1 {
+		return fmt.Errorf("could not combine %v in one request", strings.Join(modes, ", "))
+	}
+
+	if len(modes) == 1 && r.Pagination == nil && r.Retry.Count > 1 {
+		return fmt.Errorf("could not combine %v with RequestRetry", modes[0])
+	}
+
 	return nil
 }
 
 func (it *Test) validateResponse(t internalT, resp *http.Response) []error {
 	var (
-		err      error
-		saveBody io.ReadCloser
-		scope    = make([]error, 0)
+		scope = make([]error, 0)
 	)
 
 	// Execute asserts for headers
@@ -681,27 +867,42 @@ func (it *Test) validateResponse(t internalT, resp *http.Response) []error {
 		return scope
 	}
 
-	saveBody, resp.Body, err = utils.DrainBody(resp.Body)
-	if err != nil {
-		return append(scope, fmt.Errorf("could not drain response body. error %w", err))
-	}
-
-	body, err := utils.GetBody(saveBody)
+	// Body is already read in doRequest, here it's taken from buffer
+	responseBody, err := it.readResponseBody(resp)
 	if err != nil {
 		return append(scope, fmt.Errorf("could not get response body. error %w", err))
 	}
 
-	// Execute asserts for body
-	if errs := it.assertBody(t, body); len(errs) > 0 {
-		// add assert
-		scope = append(scope, errs...)
-	}
+	switch {
+	case responseBody.Truncated():
+		if it.hasBodyExpectations() {
+			scope = append(scope, cuteErrors.NewEmptyAssertError(
+				"Response body size",
+				fmt.Sprintf("response body is larger than %v bytes, body asserts are not executed", it.maxBodySize)))
+		}
+	case responseBody.decodeErr != nil:
+		// Asserts work with plaintext body, raw body is still available in AssertResponse
+		scope = append(scope, cuteErrors.NewEmptyAssertError(
+			"Response body decoding",
+			fmt.Sprintf("could not decode response body. error %v", responseBody.decodeErr)))
+	default:
+		body := responseBody.plain
+
+		// Execute asserts for body
+		if errs := it.assertBody(t, body); len(errs) > 0 {
+			// add assert
+			scope = append(scope, errs...)
+		}
 
-	// Validate response by json schema
-	if errs := it.validateJSONSchema(t, body); len(errs) > 0 {
-		scope = append(scope, errs...)
+		// Validate response by json schema
+		if errs := it.validateJSONSchema(t, body); len(errs) > 0 {
+			scope = append(scope, errs...)
+		}
 	}
 
+	// AssertResponse reads body from the beginning
+	responseBody.Rewind()
+
 	// Execute asserts for response body
 	if errs := it.assertResponse(t, resp); len(errs) > 0 {
 		scope = append(scope, errs...)
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/ozontech/allure-go/pkg/allure"
 	"github.com/ozontech/allure-go/pkg/framework/core/common"
 	"github.com/stretchr/testify/require"
 
@@ -211,9 +212,12 @@ func TestSanitizeURLHook(t *testing.T) {
 	err = test.addInformationRequest(newT, req)
 	require.NoError(t, err)
 
-	decodedQuery, err := url.QueryUnescape(req.URL.RawQuery)
+	// sanitizer is applied to copy of request, real request is not changed
+	require.Equal(t, "key=123", req.URL.RawQuery)
+
+	decodedURL, err := url.QueryUnescape(test.lastRequestURL)
 	require.NoError(t, err)
-	require.Equal(t, "key=****", decodedQuery)
+	require.Equal(t, "http://localhost/api?key=****", decodedURL)
 }
 
 func TestSanitizeURL_LastRequestURL(t *testing.T) {
@@ -272,3 +276,142 @@ func TestSanitizeURL_RealRequest(t *testing.T) {
 	allureT := createAllureT(t)
 	test.Execute(context.Background(), allureT)
 }
+
+func TestCreateRequestBuilder_ContentEncoding(t *testing.T) {
+	for _, encoding := range []string{EncodingGzip, EncodingDeflate, EncodingZstd} {
+		ht := &Test{
+			Request: &Request{
+				Builders: []RequestBuilder{
+					WithURI("http://go.com"),
+					WithMethod(http.MethodPost),
+					WithBody([]byte(`{"a":"b"}`)),
+					WithContentEncoding(encoding),
+				},
+			},
+		}
+
+		req, err := ht.createRequest(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, encoding, req.Header.Get("Content-Encoding"))
+
+		raw, err := utils.GetBody(req.Body)
+		require.NoError(t, err)
+		require.NotEqual(t, `{"a":"b"}`, string(raw))
+
+		decoded, err := utils.DecodeBody(encoding, raw)
+		require.NoError(t, err)
+		require.Equal(t, `{"a":"b"}`, string(decoded))
+	}
+}
+
+func TestExecuteCompressedResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		plain, err := utils.DecodeBody(r.Header.Get("Content-Encoding"), body)
+		require.NoError(t, err)
+		require.Equal(t, `{"name":"cute"}`, string(plain))
+
+		encoded, err := utils.EncodeBody(EncodingGzip, plain)
+		require.NoError(t, err)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", EncodingGzip)
+		_, _ = w.Write(encoded)
+	}))
+	defer ts.Close()
+
+	test := &Test{
+		httpClient: ts.Client(),
+		Request: &Request{
+			Builders: []RequestBuilder{
+				WithMethod(http.MethodPost),
+				WithURI(ts.URL),
+				WithBody([]byte(`{"name":"cute"}`)),
+				WithContentEncoding(EncodingZstd),
+				// manual Accept-Encoding disables automatic decompression in http client
+				WithHeadersKV("Accept-Encoding", EncodingGzip),
+			},
+		},
+		Expect: &Expect{
+			AssertBody: []AssertBody{
+				func(body []byte) error {
+					if string(body) != `{"name":"cute"}` {
+						return errors.New("body is not decoded")
+					}
+
+					return nil
+				},
+			},
+			AssertResponse: []AssertResponse{
+				func(resp *http.Response) error {
+					if resp.Header.Get("Content-Encoding") != EncodingGzip {
+						return errors.New("raw encoding is lost")
+					}
+
+					return nil
+				},
+			},
+		},
+	}
+
+	res := test.Execute(context.Background(), createAllureT(t))
+	require.Empty(t, res.GetErrors())
+}
+
+func TestExecuteUndecodableResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", EncodingGzip)
+		_, _ = w.Write([]byte("not gzip"))
+	}))
+	defer ts.Close()
+
+	var raw []byte
+
+	builder := NewHTTPTestMaker().NewTestBuilder().
+		Title("undecodable").
+		Create().
+		RequestBuilder(
+			WithMethod(http.MethodGet),
+			WithURI(ts.URL),
+			WithHeadersKV("Accept-Encoding", EncodingGzip),
+		).
+		AssertResponse(func(resp *http.Response) error {
+			body, err := io.ReadAll(resp.Body)
+			raw = body
+
+			return err
+		})
+
+	result, failed, _ := executeRecorded(t, builder)
+	require.True(t, failed)
+
+	// AssertResponse gets raw body, even if it could not be decoded
+	require.Equal(t, "not gzip", string(raw))
+
+	require.Len(t, result.Steps, 2)
+	require.Equal(t, "Assert response", result.Steps[1].Name)
+
+	attachment := result.Steps[0].Attachments[len(result.Steps[0].Attachments)-1]
+	require.Equal(t, "response", attachment.Name)
+	require.Equal(t, allure.MimeType("application/octet-stream"), attachment.Type)
+	require.Equal(t, "not gzip", string(attachment.GetContent()))
+
+	// Decoding error is reported separately from AssertResponse
+	ht := &Test{
+		Expect: &Expect{
+			AssertResponse: []AssertResponse{func(*http.Response) error { return errors.New("raw assert") }},
+		},
+	}
+	ht.initEmptyFields()
+
+	errs := ht.validateResponse(createAllureT(t), &http.Response{
+		Header: http.Header{"Content-Encoding": []string{EncodingGzip}},
+		Body:   io.NopCloser(strings.NewReader("not gzip")),
+	})
+	require.Len(t, errs, 2)
+	require.EqualError(t, errs[0], "could not decode response body. error could not decode gzip body: unexpected EOF")
+	require.EqualError(t, errs[1], "raw assert")
+}
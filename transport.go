@@ -0,0 +1,188 @@
+package cute
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+// Protocols of TransportConfig
+const (
+	// ProtocolHTTP1 forces HTTP/1.1
+	ProtocolHTTP1 = "http/1.1"
+	// ProtocolHTTP2 forces HTTP/2 over TLS
+	ProtocolHTTP2 = "h2"
+	// ProtocolH2C forces HTTP/2 without TLS (prior knowledge)
+	ProtocolH2C = "h2c"
+)
+
+// TransportConfig is a declarative configuration of http transport of HTTPTestMaker
+// Certificates are loaded from PEM files. Empty Protocol means HTTP/2, if server supports it, or HTTP/1.1.
+// Example in profile:
+//
+//	transport:
+//	  cert_file: certs/client.pem
+//	  key_file: certs/client-key.pem
+//	  ca_files: [certs/ca.pem]
+//	  proxy_url: http://proxy:3128
+//	  protocol: h2
+type TransportConfig struct {
+	// CertFile and KeyFile are client certificate and key for mutual TLS
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+	// CAFiles are added to system certificate pool
+	CAFiles            []string `yaml:"ca_files"`
+	ServerName         string   `yaml:"server_name"`
+	InsecureSkipVerify bool     `yaml:"insecure_skip_verify"`
+
+	// ProxyURL is url of http proxy, proxy from environment variables is used if it's empty
+	ProxyURL string `yaml:"proxy_url"`
+	// Protocol is one of http/1.1, h2 or h2c
+	Protocol string `yaml:"protocol"`
+	// UnixSocket is a path of unix socket, all requests are sent to it, host of url is used only for Host header
+	UnixSocket string `yaml:"unix_socket"`
+}
+
+// RoundTripper is a function for create http round tripper by configuration
+func (c *TransportConfig) RoundTripper() (http.RoundTripper, error) {
+	tlsConfig, err := c.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	proxy, err := c.proxy()
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}
+
+	dial := dialer.DialContext
+	if c.UnixSocket != "" {
+		dial = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", c.UnixSocket)
+		}
+	}
+
+	switch c.Protocol {
+	case "", ProtocolHTTP1:
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.Proxy = proxy
+		transport.DialContext = dial
+		transport.TLSClientConfig = tlsConfig
+
+		if c.Protocol == ProtocolHTTP1 {
+			// Non-nil empty map disables HTTP/2
+			transport.ForceAttemptHTTP2 = false
+			transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+		}
+
+		return transport, nil
+	case ProtocolHTTP2, ProtocolH2C:
+		if c.ProxyURL != "" {
+			return nil, fmt.Errorf("could not create transport error: 'proxy is not supported with protocol %v'", c.Protocol)
+		}
+
+		transport := &http2.Transport{
+			TLSClientConfig: tlsConfig,
+			DialTLSContext: func(ctx context.Context, network, addr string, cfg *tls.Config) (net.Conn, error) {
+				conn, err := dial(ctx, network, addr)
+				if err != nil {
+					return nil, err
+				}
+
+				tlsConn := tls.Client(conn, cfg)
+				if err := tlsConn.HandshakeContext(ctx); err != nil {
+					_ = conn.Close()
+
+					return nil, err
+				}
+
+				return tlsConn, nil
+			},
+		}
+
+		if c.Protocol == ProtocolH2C {
+			transport.AllowHTTP = true
+			transport.DialTLSContext = func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+				return dial(ctx, network, addr)
+			}
+		}
+
+		return transport, nil
+	}
+
+	return nil, fmt.Errorf("could not create transport error: 'unknown protocol %q, use %v, %v or %v'",
+		c.Protocol, ProtocolHTTP1, ProtocolHTTP2, ProtocolH2C)
+}
+
+func (c *TransportConfig) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify, //nolint:gosec
+	}
+
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not load client certificate error: '%s'", err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if len(c.CAFiles) != 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+
+		for _, file := range c.CAFiles {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("could not read CA file error: '%s'", err)
+			}
+
+			if !pool.AppendCertsFromPEM(data) {
+				return nil, fmt.Errorf("could not load CA file %v error: 'no PEM certificates'", file)
+			}
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+func (c *TransportConfig) proxy() (func(*http.Request) (*url.URL, error), error) {
+	if c.ProxyURL == "" {
+		if c.UnixSocket != "" {
+			return nil, nil
+		}
+
+		return http.ProxyFromEnvironment, nil
+	}
+
+	if c.UnixSocket != "" {
+		return nil, fmt.Errorf("could not create transport error: 'proxy is not supported with unix socket'")
+	}
+
+	u, err := url.Parse(c.ProxyURL)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse proxy url error: '%s'", err)
+	}
+
+	return http.ProxyURL(u), nil
+}
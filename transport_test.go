@@ -0,0 +1,192 @@
+package cute
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ozontech/allure-go/pkg/allure"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+func TestTransportMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, clientCert := writeClientCertificate(t, dir)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	srv.EnableHTTP2 = true
+	srv.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	caFile := filepath.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0600))
+
+	for protocol, expected := range map[string]string{
+		"":            "HTTP/2.0",
+		ProtocolHTTP1: "HTTP/1.1",
+		ProtocolHTTP2: "HTTP/2.0",
+	} {
+		rt, err := (&TransportConfig{
+			CertFile: certFile,
+			KeyFile:  keyFile,
+			CAFiles:  []string{caFile},
+			Protocol: protocol,
+		}).RoundTripper()
+		require.NoError(t, err)
+
+		resp, err := (&http.Client{Transport: rt}).Get(srv.URL)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+
+		require.Equal(t, expected, resp.Proto, protocol)
+		require.Equal(t, tls.VersionTLS13, int(resp.TLS.Version))
+	}
+
+	// Without client certificate server rejects connection
+	rt, err := (&TransportConfig{CAFiles: []string{caFile}}).RoundTripper()
+	require.NoError(t, err)
+
+	_, err = (&http.Client{Transport: rt}).Get(srv.URL)
+	require.Error(t, err)
+}
+
+func TestTransportH2C(t *testing.T) {
+	srv := httptest.NewServer(h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Proto))
+	}), &http2.Server{}))
+	t.Cleanup(srv.Close)
+
+	maker := NewHTTPTestMaker(WithTransport(TransportConfig{Protocol: ProtocolH2C}))
+
+	builder := maker.NewTestBuilder().
+		Title("h2c").
+		Create().
+		RequestBuilder(WithURI(srv.URL), WithMethod(http.MethodGet)).
+		ExpectStatus(http.StatusOK)
+
+	result, failed, _ := executeRecorded(t, builder)
+	require.False(t, failed)
+	require.Contains(t, result.Steps[0].Parameters, allure.NewParameter("protocol", "HTTP/2.0"))
+}
+
+func TestTransportUnixSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "cute.sock")
+
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Host))
+	}))
+	srv.Listener = listener
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	rt, err := (&TransportConfig{UnixSocket: socket}).RoundTripper()
+	require.NoError(t, err)
+
+	resp, err := (&http.Client{Transport: rt}).Get("http://service.local/users")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestTransportProxy(t *testing.T) {
+	proxied := make(chan string, 1)
+
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied <- r.URL.String()
+	}))
+	t.Cleanup(proxy.Close)
+
+	rt, err := (&TransportConfig{ProxyURL: proxy.URL}).RoundTripper()
+	require.NoError(t, err)
+
+	resp, err := (&http.Client{Transport: rt}).Get("http://service.local/users")
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, "http://service.local/users", <-proxied)
+}
+
+func TestTransportErrors(t *testing.T) {
+	for config, expected := range map[*TransportConfig]string{
+		{Protocol: "spdy"}: "unknown protocol \"spdy\"",
+		{ProxyURL: "http://proxy", UnixSocket: "/tmp/s.sock"}: "proxy is not supported with unix socket",
+		{ProxyURL: "http://proxy", Protocol: ProtocolH2C}:     "proxy is not supported with protocol h2c",
+		{CertFile: "not_found.pem", KeyFile: "not_found.pem"}: "could not load client certificate",
+		{CAFiles: []string{"transport_test.go"}}:              "could not load CA file transport_test.go error: 'no PEM certificates'",
+	} {
+		_, err := config.RoundTripper()
+		require.ErrorContains(t, err, expected)
+	}
+
+	// Tests of maker are broken, request is not sent by default transport
+	srv, methods := newFinallyServer(t)
+
+	builder := NewHTTPTestMaker(WithTransport(TransportConfig{CAFiles: []string{"not_found.pem"}})).
+		NewTestBuilder().
+		Title("transport").
+		Create().
+		RequestBuilder(WithURI(srv.URL))
+
+	result, failed, recovered := executeRecorded(t, builder)
+
+	require.Nil(t, recovered)
+	require.True(t, failed)
+	require.Equal(t, allure.Broken, result.Status)
+	require.Contains(t, result.StatusDetails.Message, "could not build transport error: 'could not read CA file error: 'open not_found.pem")
+	require.Empty(t, *methods)
+}
+
+// writeClientCertificate writes self-signed client certificate and key to dir
+func writeClientCertificate(t *testing.T, dir string) (string, string, *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "cute"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	var (
+		certFile = filepath.Join(dir, "client.pem")
+		keyFile  = filepath.Join(dir, "client-key.pem")
+	)
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+
+	return certFile, keyFile, cert
+}